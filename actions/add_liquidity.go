@@ -0,0 +1,201 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*AddLiquidity)(nil)
+	_ auth.Spender = (*AddLiquidity)(nil)
+)
+
+type AddLiquidity struct {
+	// Pool is the [TxID] of the [CreatePool].
+	Pool ids.ID `json:"pool"`
+
+	// AssetA and AssetB are the assets of [Pool] (in the same order). We need
+	// to provide these to populate [StateKeys].
+	AssetA ids.ID `json:"assetA"`
+	AssetB ids.ID `json:"assetB"`
+
+	// AmountA and AmountB are the most the actor is willing to deposit. Once
+	// [Pool] has liquidity, only the amounts that match the ratio of its
+	// reserves are deposited.
+	AmountA uint64 `json:"amountA"`
+	AmountB uint64 `json:"amountB"`
+
+	// MinShares is the fewest shares the actor is willing to receive.
+	MinShares uint64 `json:"minShares"`
+}
+
+func (a *AddLiquidity) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixPoolKey(a.Pool),
+		storage.PrefixAssetKey(a.Pool),
+		storage.PrefixBalanceKey(actor, a.Pool),
+		storage.PrefixBalanceKey(actor, a.AssetA),
+		storage.PrefixBalanceKey(actor, a.AssetB),
+		storage.PrefixFrozenKey(a.AssetA, actor),
+		storage.PrefixFrozenKey(a.AssetB, actor),
+	}
+}
+
+func (a *AddLiquidity) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := a.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, a); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if a.AmountA == 0 || a.AmountB == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	exists, assetA, assetB, reserveA, reserveB, fee, err := storage.GetPool(ctx, db, a.Pool)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputPoolMissing}, nil
+	}
+	if assetA != a.AssetA || assetB != a.AssetB {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if output := checkFrozen(ctx, db, assetA, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if output := checkFrozen(ctx, db, assetB, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	exists, shareAsset, err := storage.GetAsset(ctx, db, a.Pool)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+
+	// Determine how much to deposit and how many shares to mint
+	var amountA, amountB, shares, newSupply uint64
+	if shareAsset.Supply == 0 {
+		amountA, amountB = a.AmountA, a.AmountB
+		newSupply = initialShares(amountA, amountB)
+		if newSupply <= MinimumLiquidity {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInsufficientLiquidity}, nil
+		}
+		shares = newSupply - MinimumLiquidity
+	} else {
+		optimalB, ok := mulDiv(a.AmountA, reserveB, reserveA)
+		if ok && optimalB <= a.AmountB {
+			amountA, amountB = a.AmountA, optimalB
+		} else {
+			// [optimalA] is at most [a.AmountA] if [optimalB] is greater
+			// than [a.AmountB].
+			optimalA, _ := mulDiv(a.AmountB, reserveA, reserveB)
+			amountA, amountB = optimalA, a.AmountB
+		}
+		sharesA, _ := mulDiv(amountA, shareAsset.Supply, reserveA)
+		sharesB, _ := mulDiv(amountB, shareAsset.Supply, reserveB)
+		shares = sharesA
+		if sharesB < shares {
+			shares = sharesB
+		}
+		if amountA == 0 || amountB == 0 || shares == 0 {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInsufficientLiquidity}, nil
+		}
+		newSupply, err = smath.Add64(shareAsset.Supply, shares)
+		if err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	if shares < a.MinShares {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputBelowMinimum}, nil
+	}
+
+	// Update pool
+	newReserveA, err := smath.Add64(reserveA, amountA)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	newReserveB, err := smath.Add64(reserveB, amountB)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetPool(ctx, db, a.Pool, assetA, assetB, newReserveA, newReserveB, fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	shareAsset.Supply = newSupply
+	if err := storage.SetAsset(ctx, db, a.Pool, shareAsset); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+
+	// Update balances
+	if err := storage.SubBalance(ctx, db, actor, assetA, amountA); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SubBalance(ctx, db, actor, assetB, amountB); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, a.Pool, shares); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	lr := &LiquidityResult{AmountA: amountA, AmountB: amountB, Shares: shares}
+	output, err := lr.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, Output: output}, nil
+}
+
+func (*AddLiquidity) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*3 + consts.Uint64Len*3
+}
+
+func (a *AddLiquidity) Marshal(p *codec.Packer) {
+	p.PackID(a.Pool)
+	p.PackID(a.AssetA)
+	p.PackID(a.AssetB)
+	p.PackUint64(a.AmountA)
+	p.PackUint64(a.AmountB)
+	p.PackUint64(a.MinShares)
+}
+
+func UnmarshalAddLiquidity(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var add AddLiquidity
+	p.UnpackID(true, &add.Pool)
+	p.UnpackID(false, &add.AssetA) // empty ID is the native asset
+	p.UnpackID(false, &add.AssetB) // empty ID is the native asset
+	add.AmountA = p.UnpackUint64(true)
+	add.AmountB = p.UnpackUint64(true)
+	add.MinShares = p.UnpackUint64(false)
+	return &add, p.Err()
+}
+
+func (*AddLiquidity) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (a *AddLiquidity) Spends() []*auth.Amount {
+	// [AmountA] and [AmountB] are the most that can be deposited.
+	return []*auth.Amount{{Asset: a.AssetA, Value: a.AmountA}, {Asset: a.AssetB, Value: a.AmountB}}
+}
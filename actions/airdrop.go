@@ -0,0 +1,92 @@
+package actions
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+)
+
+const (
+	airdropLeafPrefix = 0x0
+	airdropNodePrefix = 0x1
+)
+
+// AirdropLeaf returns the leaf committed to by an airdrop Merkle root that
+// entitles [account] to claim [amount] as entry [index].
+//
+// Leaves and nodes are hashed with different prefixes so that a node can
+// never be passed off as a leaf.
+func AirdropLeaf(index uint64, account crypto.PublicKey, amount uint64) ids.ID {
+	b := make([]byte, 1+consts.Uint64Len+crypto.PublicKeyLen+consts.Uint64Len)
+	b[0] = airdropLeafPrefix
+	binary.BigEndian.PutUint64(b[1:], index)
+	copy(b[1+consts.Uint64Len:], account[:])
+	binary.BigEndian.PutUint64(b[1+consts.Uint64Len+crypto.PublicKeyLen:], amount)
+	return utils.ToID(b)
+}
+
+// airdropNode hashes two siblings in sorted order, so proofs don't need to
+// specify which side each sibling is on.
+func airdropNode(a ids.ID, b ids.ID) ids.ID {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	v := make([]byte, 1+consts.IDLen*2)
+	v[0] = airdropNodePrefix
+	copy(v[1:], a[:])
+	copy(v[1+consts.IDLen:], b[:])
+	return utils.ToID(v)
+}
+
+// VerifyAirdropProof returns true if [proof] shows that [leaf] is included in
+// the tree with [root].
+func VerifyAirdropProof(root ids.ID, leaf ids.ID, proof []ids.ID) bool {
+	node := leaf
+	for _, sibling := range proof {
+		node = airdropNode(node, sibling)
+	}
+	return node == root
+}
+
+// BuildAirdropTree returns the root of the tree with [leaves] and the proof of
+// each leaf (in the same order as [leaves]).
+//
+// If a level has an odd number of nodes, the last one is promoted to the next
+// level without being hashed.
+func BuildAirdropTree(leaves []ids.ID) (ids.ID, [][]ids.ID) {
+	if len(leaves) == 0 {
+		return ids.Empty, nil
+	}
+	proofs := make([][]ids.ID, len(leaves))
+
+	// [positions] tracks the index of the node that contains each leaf at the
+	// current level.
+	positions := make([]int, len(leaves))
+	for i := range positions {
+		positions[i] = i
+	}
+	level := leaves
+	for len(level) > 1 {
+		for i, pos := range positions {
+			sibling := pos ^ 1
+			if sibling < len(level) {
+				proofs[i] = append(proofs[i], level[sibling])
+			}
+			positions[i] = pos / 2
+		}
+		next := make([]ids.ID, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, airdropNode(level[i], level[i+1]))
+		}
+		level = next
+	}
+	return level[0], proofs
+}
@@ -0,0 +1,92 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*Approve)(nil)
+	_ auth.Spender = (*Approve)(nil)
+)
+
+type Approve struct {
+	// Spender is allowed to move up to [Value] of [Asset] out of the actor's
+	// balance using [TransferFrom].
+	Spender crypto.PublicKey `json:"spender"`
+
+	// Asset that [Spender] is allowed to move.
+	Asset ids.ID `json:"asset"`
+
+	// Value replaces any existing allowance. Setting [Value] to 0 revokes the
+	// allowance.
+	Value uint64 `json:"value"`
+}
+
+func (a *Approve) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixAllowanceKey(auth.GetActor(rauth), a.Spender, a.Asset),
+	}
+}
+
+func (a *Approve) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := a.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, a); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if a.Spender == actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSelfApproval}, nil
+	}
+	if err := storage.SetAllowance(ctx, db, actor, a.Spender, a.Asset, a.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*Approve) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen + consts.IDLen + consts.Uint64Len
+}
+
+func (a *Approve) Marshal(p *codec.Packer) {
+	p.PackPublicKey(a.Spender)
+	p.PackID(a.Asset)
+	p.PackUint64(a.Value)
+}
+
+func UnmarshalApprove(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var approve Approve
+	p.UnpackPublicKey(true, &approve.Spender)
+	p.UnpackID(false, &approve.Asset)     // empty ID is the native asset
+	approve.Value = p.UnpackUint64(false) // 0 revokes the allowance
+	return &approve, p.Err()
+}
+
+func (*Approve) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (a *Approve) Spends() []*auth.Amount {
+	// The allowance granted to [Spender] can be spent without the session key.
+	return []*auth.Amount{{Asset: a.Asset, Value: a.Value}}
+}
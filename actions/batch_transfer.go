@@ -0,0 +1,208 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*BatchTransfer)(nil)
+	_ auth.Spender = (*BatchTransfer)(nil)
+)
+
+type BatchTransferEntry struct {
+	// To is the recipient of the [Value].
+	To crypto.PublicKey `json:"to"`
+
+	// Asset to transfer to [To].
+	Asset ids.ID `json:"asset"`
+
+	// Amount are transferred to [To].
+	Value uint64 `json:"value"`
+}
+
+type BatchTransfer struct {
+	// Entries are applied in order. If any entry fails, none of them are
+	// applied.
+	Entries []*BatchTransferEntry `json:"entries"`
+}
+
+func (b *BatchTransfer) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	var (
+		actor = auth.GetActor(rauth)
+		keys  = make([][]byte, 0, len(b.Entries)*6)
+		seen  = set.NewSet[string](len(b.Entries) * 6)
+	)
+	for _, entry := range b.Entries {
+		for _, k := range [][]byte{
+			storage.PrefixBalanceKey(actor, entry.Asset),
+			storage.PrefixBalanceKey(entry.To, entry.Asset),
+			storage.PrefixFrozenKey(entry.Asset, actor),
+			storage.PrefixFrozenKey(entry.Asset, entry.To),
+			storage.PrefixAssetKey(entry.Asset),
+			storage.PrefixFeesKey(entry.Asset),
+		} {
+			if seen.Contains(string(k)) {
+				continue
+			}
+			seen.Add(string(k))
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (b *BatchTransfer) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := b.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, b); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if len(b.Entries) == 0 {
+		// This should be guarded via [Unmarshal] but we check anyways.
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNoEntries}, nil
+	}
+	for _, entry := range b.Entries {
+		if entry.Value == 0 {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+		}
+	}
+	// If any entry fails, the changes made by prior entries are discarded
+	// because the result is not successful.
+	var (
+		fees     = make([]uint64, len(b.Entries))
+		withheld = false
+	)
+	for i, entry := range b.Entries {
+		if output := checkFrozen(ctx, db, entry.Asset, actor, entry.To); output != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+		}
+		if err := storage.SubBalance(ctx, db, actor, entry.Asset, entry.Value); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		fee, err := withholdFee(ctx, db, entry.Asset, entry.Value)
+		if err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if err := storage.AddBalance(ctx, db, entry.To, entry.Asset, entry.Value-fee); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		fees[i] = fee
+		withheld = withheld || fee > 0
+	}
+	if !withheld {
+		// Transfers that don't withhold a fee have no output.
+		return &chain.Result{Success: true, Units: unitsUsed}, nil
+	}
+	br := &BatchTransferResult{Fees: fees}
+	output, err := br.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, Output: output}, nil
+}
+
+func (b *BatchTransfer) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return uint64(len(b.Entries)) * (crypto.PublicKeyLen + consts.IDLen + consts.Uint64Len)
+}
+
+func (b *BatchTransfer) Marshal(p *codec.Packer) {
+	p.PackInt(len(b.Entries))
+	for _, entry := range b.Entries {
+		p.PackPublicKey(entry.To)
+		p.PackID(entry.Asset)
+		p.PackUint64(entry.Value)
+	}
+}
+
+func UnmarshalBatchTransfer(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var batch BatchTransfer
+	count := p.UnpackInt(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if count > MaxBatchTransferEntries {
+		return nil, ErrTooManyEntries
+	}
+	batch.Entries = make([]*BatchTransferEntry, count)
+	for i := 0; i < count; i++ {
+		var entry BatchTransferEntry
+		p.UnpackPublicKey(false, &entry.To) // can transfer to blackhole
+		p.UnpackID(false, &entry.Asset)     // empty ID is the native asset
+		entry.Value = p.UnpackUint64(true)
+		batch.Entries[i] = &entry
+	}
+	return &batch, p.Err()
+}
+
+func (*BatchTransfer) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+// BatchTransferResult is the output of a successful [BatchTransfer] that
+// withheld a fee. [Fees] contains the amount withheld from each entry, in
+// order.
+type BatchTransferResult struct {
+	Fees []uint64 `json:"fees"`
+}
+
+func UnmarshalBatchTransferResult(b []byte) (*BatchTransferResult, error) {
+	p := codec.NewReader(b, consts.IntLen+MaxBatchTransferEntries*consts.Uint64Len)
+	var result BatchTransferResult
+	count := p.UnpackInt(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if count > MaxBatchTransferEntries {
+		return nil, ErrTooManyEntries
+	}
+	result.Fees = make([]uint64, count)
+	for i := 0; i < count; i++ {
+		result.Fees[i] = p.UnpackUint64(false)
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &result, nil
+}
+
+func (b *BatchTransferResult) Marshal() ([]byte, error) {
+	p := codec.NewWriter(consts.IntLen + len(b.Fees)*consts.Uint64Len)
+	p.PackInt(len(b.Fees))
+	for _, fee := range b.Fees {
+		p.PackUint64(fee)
+	}
+	return p.Bytes(), p.Err()
+}
+
+func (b *BatchTransfer) Spends() []*auth.Amount {
+	spends := make([]*auth.Amount, len(b.Entries))
+	for i, entry := range b.Entries {
+		spends[i] = &auth.Amount{Asset: entry.Asset, Value: entry.Value}
+	}
+	return spends
+}
@@ -0,0 +1,114 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*BurnAsset)(nil)
+	_ auth.Spender = (*BurnAsset)(nil)
+)
+
+type BurnAsset struct {
+	// Asset is the [TxID] that created the asset.
+	Asset ids.ID `json:"asset"`
+
+	// Number of assets to burn from the balance of the actor.
+	Value uint64 `json:"value"`
+}
+
+func (b *BurnAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixAssetKey(b.Asset),
+		storage.PrefixBalanceKey(actor, b.Asset),
+		storage.PrefixFrozenKey(b.Asset, actor),
+	}
+}
+
+func (b *BurnAsset) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := b.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, b); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if b.Asset == ids.Empty {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetIsNative}, nil
+	}
+	if b.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	exists, asset, err := storage.GetAsset(ctx, db, b.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if asset.Warp {
+		// Warp assets can only be removed from circulation by exporting them
+		// back to their source chain.
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
+	}
+	if output := checkFrozen(ctx, db, b.Asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SubBalance(ctx, db, actor, b.Asset, b.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	newSupply, err := smath.Sub(asset.Supply, b.Value)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	asset.Supply = newSupply
+	if err := storage.SetAsset(ctx, db, b.Asset, asset); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*BurnAsset) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + consts.Uint64Len
+}
+
+func (b *BurnAsset) Marshal(p *codec.Packer) {
+	p.PackID(b.Asset)
+	p.PackUint64(b.Value)
+}
+
+func UnmarshalBurnAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var burn BurnAsset
+	p.UnpackID(true, &burn.Asset) // empty ID is the native asset
+	burn.Value = p.UnpackUint64(true)
+	return &burn, p.Err()
+}
+
+func (*BurnAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (b *BurnAsset) Spends() []*auth.Amount {
+	return []*auth.Amount{{Asset: b.Asset, Value: b.Value}}
+}
@@ -0,0 +1,102 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var _ chain.Action = (*BurnNFT)(nil)
+
+type BurnNFT struct {
+	// Collection is the [TxID] that created the collection.
+	Collection ids.ID `json:"collection"`
+
+	// ID identifies the NFT within [Collection]. The actor must be the owner
+	// of the NFT.
+	ID uint64 `json:"id"`
+}
+
+func (b *BurnNFT) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixCollectionKey(b.Collection),
+		storage.PrefixNFTKey(b.Collection, b.ID),
+		storage.PrefixBurnedNFTKey(b.Collection, b.ID),
+	}
+}
+
+func (b *BurnNFT) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := b.MaxUnits(r) // max units == units
+	exists, owner, _, err := storage.GetNFT(ctx, db, b.Collection, b.ID)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNFTMissing}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	exists, metadata, collectionOwner, supply, err := storage.GetCollection(ctx, db, b.Collection)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputCollectionMissing}, nil
+	}
+	newSupply, err := smath.Sub(supply, 1)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetCollection(ctx, db, b.Collection, metadata, collectionOwner, newSupply); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.DeleteNFT(ctx, db, b.Collection, b.ID); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetNFTBurned(ctx, db, b.Collection, b.ID); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*BurnNFT) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + consts.Uint64Len
+}
+
+func (b *BurnNFT) Marshal(p *codec.Packer) {
+	p.PackID(b.Collection)
+	p.PackUint64(b.ID)
+}
+
+func UnmarshalBurnNFT(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var burn BurnNFT
+	p.UnpackID(true, &burn.Collection)
+	burn.ID = p.UnpackUint64(false)
+	return &burn, p.Err()
+}
+
+func (*BurnNFT) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,144 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*CancelStream)(nil)
+	_ auth.Spender = (*CancelStream)(nil)
+)
+
+type CancelStream struct {
+	// Stream is the [TxID] of the [CreateStream] to cancel. The actor must be
+	// the sender of [Stream].
+	//
+	// Whatever has accrued to the recipient (and has not been withdrawn) is
+	// paid to them and the rest of the deposit is returned to the actor.
+	Stream ids.ID `json:"stream"`
+
+	// Asset and Recipient are the asset and recipient of [Stream]. We need to
+	// provide these to populate [StateKeys].
+	Asset     ids.ID           `json:"asset"`
+	Recipient crypto.PublicKey `json:"recipient"`
+}
+
+func (c *CancelStream) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixStreamKey(c.Stream),
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixBalanceKey(c.Recipient, c.Asset),
+		storage.PrefixFrozenKey(c.Asset, actor),
+		storage.PrefixFrozenKey(c.Asset, c.Recipient),
+		storage.PrefixAssetKey(c.Asset),
+		storage.PrefixFeesKey(c.Asset),
+	}
+}
+
+func (c *CancelStream) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	exists, sender, recipient, asset, deposit, rate, start, withdrawn, err := storage.GetStream(ctx, db, c.Stream)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputStreamMissing}, nil
+	}
+	if sender != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if asset != c.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if recipient != c.Recipient {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongDestination}, nil
+	}
+	var (
+		streamed = StreamedAmount(deposit, rate, start, t)
+		refund   = deposit - streamed
+		owed     = streamed - withdrawn
+	)
+
+	// Only the parties that receive funds must not be frozen, so a frozen
+	// recipient can't prevent the sender from cancelling a fully streamed
+	// stream (and vice versa).
+	if refund > 0 {
+		if output := checkFrozen(ctx, db, asset, actor); output != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+		}
+	}
+	if owed > 0 {
+		if output := checkFrozen(ctx, db, asset, recipient); output != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+		}
+	}
+	if err := storage.DeleteStream(ctx, db, c.Stream); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if refund > 0 {
+		if err := storage.AddBalance(ctx, db, actor, asset, refund); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	var fee uint64
+	if owed > 0 {
+		fee, err = withholdFee(ctx, db, asset, owed)
+		if err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if err := storage.AddBalance(ctx, db, recipient, asset, owed-fee); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	return transferResult(unitsUsed, fee)
+}
+
+func (*CancelStream) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*2 + crypto.PublicKeyLen
+}
+
+func (c *CancelStream) Marshal(p *codec.Packer) {
+	p.PackID(c.Stream)
+	p.PackID(c.Asset)
+	p.PackPublicKey(c.Recipient)
+}
+
+func UnmarshalCancelStream(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var cancel CancelStream
+	p.UnpackID(true, &cancel.Stream)
+	p.UnpackID(false, &cancel.Asset) // empty ID is the native asset
+	p.UnpackPublicKey(true, &cancel.Recipient)
+	return &cancel, p.Err()
+}
+
+func (*CancelStream) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*CancelStream) Spends() []*auth.Amount {
+	// Cancelling a stream only pays out funds that are already deposited.
+	return nil
+}
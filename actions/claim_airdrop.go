@@ -0,0 +1,164 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*ClaimAirdrop)(nil)
+	_ auth.Spender = (*ClaimAirdrop)(nil)
+)
+
+type ClaimAirdrop struct {
+	// Airdrop is the [TxID] of the [CreateAirdrop] to claim from.
+	Airdrop ids.ID `json:"airdrop"`
+
+	// Asset is the asset distributed by [Airdrop]. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+
+	// Index and Amount identify the entry being claimed. The entry must be
+	// for the actor.
+	Index  uint64 `json:"index"`
+	Amount uint64 `json:"amount"`
+
+	// Proof contains the siblings of the entry's leaf, from the bottom of the
+	// tree to the top.
+	Proof []ids.ID `json:"proof"`
+}
+
+func (c *ClaimAirdrop) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixAirdropKey(c.Airdrop),
+		storage.PrefixAirdropClaimKey(c.Airdrop, c.Index),
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixFrozenKey(c.Asset, actor),
+		storage.PrefixAssetKey(c.Asset),
+		storage.PrefixFeesKey(c.Asset),
+	}
+}
+
+func (c *ClaimAirdrop) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if c.Amount == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	exists, creator, asset, root, entries, remaining, expiry, err := storage.GetAirdrop(ctx, db, c.Airdrop)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAirdropMissing}, nil
+	}
+	if asset != c.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if t >= expiry {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAirdropExpired}, nil
+	}
+	if c.Index >= entries {
+		// Claims are only tracked (and deleted by [ReclaimAirdrop]) for
+		// [entries], even if [root] commits to more.
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputIndexOutOfRange}, nil
+	}
+	if output := checkFrozen(ctx, db, asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	claimed, err := storage.GetAirdropClaimed(ctx, db, c.Airdrop, c.Index)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if claimed {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAlreadyClaimed}, nil
+	}
+	if !VerifyAirdropProof(root, AirdropLeaf(c.Index, actor, c.Amount), c.Proof) {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInvalidProof}, nil
+	}
+	// This can only fail if the entries committed to by [root] add up to more
+	// than was escrowed.
+	newRemaining, err := smath.Sub(remaining, c.Amount)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetAirdrop(ctx, db, c.Airdrop, creator, asset, root, entries, newRemaining, expiry); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetAirdropClaimed(ctx, db, c.Airdrop, c.Index); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	fee, err := withholdFee(ctx, db, asset, c.Amount)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, asset, c.Amount-fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return transferResult(unitsUsed, fee)
+}
+
+func (c *ClaimAirdrop) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*2 + consts.Uint64Len*2 + uint64(len(c.Proof))*consts.IDLen
+}
+
+func (c *ClaimAirdrop) Marshal(p *codec.Packer) {
+	p.PackID(c.Airdrop)
+	p.PackID(c.Asset)
+	p.PackUint64(c.Index)
+	p.PackUint64(c.Amount)
+	p.PackInt(len(c.Proof))
+	for _, sibling := range c.Proof {
+		p.PackID(sibling)
+	}
+}
+
+func UnmarshalClaimAirdrop(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var claim ClaimAirdrop
+	p.UnpackID(true, &claim.Airdrop)
+	p.UnpackID(false, &claim.Asset) // empty ID is the native asset
+	claim.Index = p.UnpackUint64(false)
+	claim.Amount = p.UnpackUint64(true)
+	depth := p.UnpackInt(false) // empty when the airdrop has a single entry
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if depth > MaxAirdropProofDepth {
+		return nil, ErrProofTooLong
+	}
+	claim.Proof = make([]ids.ID, depth)
+	for i := 0; i < depth; i++ {
+		p.UnpackID(true, &claim.Proof[i])
+	}
+	return &claim, p.Err()
+}
+
+func (*ClaimAirdrop) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*ClaimAirdrop) Spends() []*auth.Amount {
+	// The claimed entry is paid to the actor.
+	return nil
+}
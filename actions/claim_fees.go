@@ -0,0 +1,102 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*ClaimFees)(nil)
+	_ auth.Spender = (*ClaimFees)(nil)
+)
+
+type ClaimFees struct {
+	// Asset is the [TxID] that created the asset. The actor must be the
+	// [FeeRecipient] of the asset.
+	Asset ids.ID `json:"asset"`
+}
+
+func (c *ClaimFees) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixAssetKey(c.Asset),
+		storage.PrefixFeesKey(c.Asset),
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixFrozenKey(c.Asset, actor),
+	}
+}
+
+func (c *ClaimFees) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	exists, asset, err := storage.GetAsset(ctx, db, c.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if asset.FeeRecipient != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if output := checkFrozen(ctx, db, c.Asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	fees, err := storage.GetFees(ctx, db, c.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if fees == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNoFees}, nil
+	}
+	if err := storage.DeleteFees(ctx, db, c.Asset); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, c.Asset, fees); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*ClaimFees) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen
+}
+
+func (c *ClaimFees) Marshal(p *codec.Packer) {
+	p.PackID(c.Asset)
+}
+
+func UnmarshalClaimFees(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var claim ClaimFees
+	p.UnpackID(false, &claim.Asset) // empty ID is the native asset
+	return &claim, p.Err()
+}
+
+func (*ClaimFees) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*ClaimFees) Spends() []*auth.Amount {
+	// The accrued fees are paid to the actor.
+	return nil
+}
@@ -0,0 +1,118 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*ClaimHTLC)(nil)
+	_ auth.Spender = (*ClaimHTLC)(nil)
+)
+
+type ClaimHTLC struct {
+	// HTLC is the [TxID] of the [LockHTLC] to claim.
+	HTLC ids.ID `json:"htlc"`
+
+	// Asset is the asset locked in [HTLC]. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+
+	// Preimage must hash to the [Hash] provided in [LockHTLC].
+	Preimage []byte `json:"preimage"`
+}
+
+func (c *ClaimHTLC) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixHTLCKey(c.HTLC),
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixFrozenKey(c.Asset, actor),
+		storage.PrefixAssetKey(c.Asset),
+		storage.PrefixFeesKey(c.Asset),
+	}
+}
+
+func (c *ClaimHTLC) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	exists, _, to, asset, value, hash, expiry, err := storage.GetHTLC(ctx, db, c.HTLC)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputHTLCMissing}, nil
+	}
+	if to != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if asset != c.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if t >= expiry {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputHTLCExpired}, nil
+	}
+	if utils.ToID(c.Preimage) != hash {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongPreimage}, nil
+	}
+	if output := checkFrozen(ctx, db, asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.DeleteHTLC(ctx, db, c.HTLC); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	fee, err := withholdFee(ctx, db, asset, value)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, asset, value-fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return transferResult(unitsUsed, fee)
+}
+
+func (c *ClaimHTLC) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*2 + uint64(len(c.Preimage))
+}
+
+func (c *ClaimHTLC) Marshal(p *codec.Packer) {
+	p.PackID(c.HTLC)
+	p.PackID(c.Asset)
+	p.PackBytes(c.Preimage)
+}
+
+func UnmarshalClaimHTLC(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var claim ClaimHTLC
+	p.UnpackID(true, &claim.HTLC)
+	p.UnpackID(false, &claim.Asset) // empty ID is the native asset
+	p.UnpackBytes(MaxPreimageSize, true, &claim.Preimage)
+	return &claim, p.Err()
+}
+
+func (*ClaimHTLC) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*ClaimHTLC) Spends() []*auth.Amount {
+	// The locked funds are paid to the actor.
+	return nil
+}
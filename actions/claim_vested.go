@@ -0,0 +1,121 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*ClaimVested)(nil)
+	_ auth.Spender = (*ClaimVested)(nil)
+)
+
+type ClaimVested struct {
+	// Vesting is the [TxID] of the [CreateVesting] to claim from.
+	Vesting ids.ID `json:"vesting"`
+
+	// Asset is the asset locked in [Vesting]. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+}
+
+func (c *ClaimVested) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixVestingKey(c.Vesting),
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixFrozenKey(c.Asset, actor),
+		storage.PrefixAssetKey(c.Asset),
+		storage.PrefixFeesKey(c.Asset),
+	}
+}
+
+func (c *ClaimVested) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	exists, beneficiary, asset, total, claimed, start, cliff, duration, err := storage.GetVesting(ctx, db, c.Vesting)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputVestingMissing}, nil
+	}
+	if beneficiary != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if asset != c.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if output := checkFrozen(ctx, db, asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	vested := VestedAmount(total, start, cliff, duration, t)
+	if vested <= claimed {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNothingVested}, nil
+	}
+	if vested == total {
+		// Nothing else can be claimed, so we remove the schedule.
+		if err := storage.DeleteVesting(ctx, db, c.Vesting); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	} else {
+		if err := storage.SetVesting(
+			ctx, db, c.Vesting, beneficiary, asset,
+			total, vested, start, cliff, duration,
+		); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	fee, err := withholdFee(ctx, db, asset, vested-claimed)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, asset, vested-claimed-fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return transferResult(unitsUsed, fee)
+}
+
+func (*ClaimVested) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen * 2
+}
+
+func (c *ClaimVested) Marshal(p *codec.Packer) {
+	p.PackID(c.Vesting)
+	p.PackID(c.Asset)
+}
+
+func UnmarshalClaimVested(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var claim ClaimVested
+	p.UnpackID(true, &claim.Vesting)
+	p.UnpackID(false, &claim.Asset) // empty ID is the native asset
+	return &claim, p.Err()
+}
+
+func (*ClaimVested) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*ClaimVested) Spends() []*auth.Amount {
+	// The vested funds are paid to the actor.
+	return nil
+}
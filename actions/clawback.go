@@ -0,0 +1,90 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var _ chain.Action = (*Clawback)(nil)
+
+type Clawback struct {
+	// Asset is the [TxID] that created the asset. It must have been created
+	// with [Regulated] set.
+	Asset ids.ID `json:"asset"`
+
+	// From is the account that [Value] is taken from. Funds can be clawed
+	// back from frozen accounts.
+	From crypto.PublicKey `json:"from"`
+
+	// Value of [Asset] that is sent to the owner of [Asset].
+	Value uint64 `json:"value"`
+}
+
+func (c *Clawback) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixAssetKey(c.Asset),
+		storage.PrefixBalanceKey(c.From, c.Asset),
+		storage.PrefixBalanceKey(auth.GetActor(rauth), c.Asset),
+	}
+}
+
+func (c *Clawback) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if c.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if output := checkRegulator(ctx, db, c.Asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SubBalance(ctx, db, c.From, c.Asset, c.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, c.Asset, c.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*Clawback) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + crypto.PublicKeyLen + consts.Uint64Len
+}
+
+func (c *Clawback) Marshal(p *codec.Packer) {
+	p.PackID(c.Asset)
+	p.PackPublicKey(c.From)
+	p.PackUint64(c.Value)
+}
+
+func UnmarshalClawback(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var clawback Clawback
+	p.UnpackID(true, &clawback.Asset) // the native asset is never regulated
+	p.UnpackPublicKey(false, &clawback.From)
+	clawback.Value = p.UnpackUint64(true)
+	return &clawback, p.Err()
+}
+
+func (*Clawback) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -1,3 +1,44 @@
 package actions
 
-const MaxMetadataSize = 256
+const (
+	MaxMetadataSize = 256
+	MaxMemoSize     = 128
+	MaxPreimageSize = 64
+	MaxSymbolSize   = 8
+	MaxNameSize     = 64
+	MaxURISize      = 256
+
+	// MaxDecimals is the largest number of decimal places an asset can use,
+	// which matches the native asset.
+	MaxDecimals = 9
+
+	// MaxBatchTransferEntries is the maximum number of entries that can be
+	// included in a single [BatchTransfer].
+	MaxBatchTransferEntries = 64
+
+	// MaxSequenceActions is the maximum number of actions that can be
+	// included in a single [Sequence].
+	MaxSequenceActions = 16
+
+	// BasisPoints is the denominator of [CreateAsset.TransferFee]. A
+	// [TransferFee] must be less than [BasisPoints] (100%).
+	BasisPoints = 10_000
+
+	// MaxAirdropEntries is the maximum number of entries in a
+	// [CreateAirdrop]. It bounds the number of claim keys that
+	// [ReclaimAirdrop] deletes.
+	MaxAirdropEntries = 16_384
+
+	// MaxAirdropProofDepth is the maximum number of siblings in a
+	// [ClaimAirdrop] proof, which is enough for [MaxAirdropEntries] entries.
+	MaxAirdropProofDepth = 14
+
+	// MinimumLiquidity is the number of shares that are locked forever when
+	// liquidity is first added to a pool, so that a pool can never be fully
+	// drained.
+	MinimumLiquidity = 1_000
+
+	// PoolShareSymbol is the symbol of the asset created by [CreatePool] to
+	// track the liquidity shares of a pool.
+	PoolShareSymbol = "LP"
+)
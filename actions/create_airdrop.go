@@ -0,0 +1,122 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*CreateAirdrop)(nil)
+	_ auth.Spender = (*CreateAirdrop)(nil)
+)
+
+type CreateAirdrop struct {
+	// Asset to distribute. This can be the native asset.
+	Asset ids.ID `json:"asset"`
+
+	// Value is the total amount of [Asset] escrowed for the airdrop. It
+	// should equal the sum of all entries committed to by [Root].
+	Value uint64 `json:"value"`
+
+	// Root is the Merkle root of the airdrop entries (see [AirdropLeaf] and
+	// [BuildAirdropTree]). Each entry can be claimed once using
+	// [ClaimAirdrop].
+	Root ids.ID `json:"root"`
+
+	// Entries is the number of entries committed to by [Root], which must be
+	// indexed from 0 to [Entries]-1.
+	Entries uint64 `json:"entries"`
+
+	// Expiry is the unix timestamp (in seconds) at which entries can no
+	// longer be claimed and the actor can reclaim whatever is left using
+	// [ReclaimAirdrop].
+	Expiry int64 `json:"expiry"`
+}
+
+func (c *CreateAirdrop) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixFrozenKey(c.Asset, actor),
+		storage.PrefixAirdropKey(txID),
+	}
+}
+
+func (c *CreateAirdrop) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, c); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if c.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if c.Expiry <= t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputExpiryInPast}, nil
+	}
+	if output := checkFrozen(ctx, db, c.Asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SubBalance(ctx, db, actor, c.Asset, c.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetAirdrop(ctx, db, txID, actor, c.Asset, c.Root, c.Entries, c.Value, c.Expiry); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CreateAirdrop) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*2 + consts.Uint64Len*3
+}
+
+func (c *CreateAirdrop) Marshal(p *codec.Packer) {
+	p.PackID(c.Asset)
+	p.PackUint64(c.Value)
+	p.PackID(c.Root)
+	p.PackUint64(c.Entries)
+	p.PackInt64(c.Expiry)
+}
+
+func UnmarshalCreateAirdrop(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateAirdrop
+	p.UnpackID(false, &create.Asset) // empty ID is the native asset
+	create.Value = p.UnpackUint64(true)
+	p.UnpackID(true, &create.Root)
+	create.Entries = p.UnpackUint64(true)
+	create.Expiry = p.UnpackInt64(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if create.Entries > MaxAirdropEntries {
+		return nil, ErrTooManyEntries
+	}
+	return &create, nil
+}
+
+func (*CreateAirdrop) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (c *CreateAirdrop) Spends() []*auth.Amount {
+	return []*auth.Amount{{Asset: c.Asset, Value: c.Value}}
+}
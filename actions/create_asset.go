@@ -7,17 +7,54 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/utils"
 	"github.com/rafael-abuawad/samplevm/auth"
 	"github.com/rafael-abuawad/samplevm/storage"
 )
 
-var _ chain.Action = (*CreateAsset)(nil)
+var (
+	_ chain.Action = (*CreateAsset)(nil)
+	_ auth.Spender = (*CreateAsset)(nil)
+)
 
 type CreateAsset struct {
+	// Symbol is the ticker of the asset (like "TKN"). It must be uppercase
+	// alphanumeric.
+	Symbol []byte `json:"symbol"`
+
+	// Decimals is the number of decimal places used to display balances of
+	// the asset. All amounts on-chain are denominated in the smallest unit.
+	Decimals uint8 `json:"decimals"`
+
+	// Name is the human-readable name of the asset.
+	Name []byte `json:"name"`
+
+	// URI optionally points to an off-chain document describing the asset
+	// and URIHash is the hash of that document (so that it can't be changed
+	// without notice). URIHash must be provided if URI is set.
+	URI     []byte `json:"uri"`
+	URIHash ids.ID `json:"uriHash"`
+
 	// Metadata is creator-specified information about the asset. This can be
 	// modified using the [ModifyAsset] action.
 	Metadata []byte `json:"metadata"`
+
+	// MaxSupply is the maximum amount of the asset that can ever be minted. If
+	// 0, the supply of the asset is not capped.
+	MaxSupply uint64 `json:"maxSupply"`
+
+	// Regulated allows the owner of the asset to freeze accounts (using
+	// [FreezeAccount] and [UnfreezeAccount]) and to claw back funds (using
+	// [Clawback]). This can't be changed after the asset is created.
+	Regulated bool `json:"regulated"`
+
+	// TransferFee is withheld from every transfer of the asset (in basis
+	// points) and can be claimed by [FeeRecipient] using [ClaimFees]. If 0,
+	// transfers of the asset are free.
+	TransferFee  uint64           `json:"transferFee"`
+	FeeRecipient crypto.PublicKey `json:"feeRecipient"`
 }
 
 func (*CreateAsset) StateKeys(_ chain.Auth, txID ids.ID) [][]byte {
@@ -35,12 +72,57 @@ func (c *CreateAsset) Execute(
 ) (*chain.Result, error) {
 	actor := auth.GetActor(rauth)
 	unitsUsed := c.MaxUnits(r) // max units == units
+	if len(c.Symbol) == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSymbolEmpty}, nil
+	}
+	if len(c.Symbol) > MaxSymbolSize {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSymbolTooLarge}, nil
+	}
+	if !ValidSymbol(c.Symbol) {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSymbolInvalid}, nil
+	}
+	if c.Decimals > MaxDecimals {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputDecimalsTooLarge}, nil
+	}
+	if len(c.Name) == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNameEmpty}, nil
+	}
+	if len(c.Name) > MaxNameSize {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNameTooLarge}, nil
+	}
+	if len(c.URI) > MaxURISize {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputURITooLarge}, nil
+	}
+	if len(c.URI) > 0 && c.URIHash == ids.Empty {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputURIHashEmpty}, nil
+	}
+	if len(c.URI) == 0 && c.URIHash != ids.Empty {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputURIEmpty}, nil
+	}
 	if len(c.Metadata) > MaxMetadataSize {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMetadataTooLarge}, nil
 	}
+	if c.TransferFee >= BasisPoints {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputFeeTooLarge}, nil
+	}
+	if c.TransferFee > 0 && c.FeeRecipient == crypto.EmptyPublicKey {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputFeeRecipientEmpty}, nil
+	}
 	// It should only be possible to overwrite an existing asset if there is
 	// a hash collision.
-	if err := storage.SetAsset(ctx, db, txID, c.Metadata, 0, actor, false); err != nil {
+	if err := storage.SetAsset(ctx, db, txID, &storage.Asset{
+		Symbol:       c.Symbol,
+		Decimals:     c.Decimals,
+		Name:         c.Name,
+		URI:          c.URI,
+		URIHash:      c.URIHash,
+		Metadata:     c.Metadata,
+		MaxSupply:    c.MaxSupply,
+		Owner:        actor,
+		Regulated:    c.Regulated,
+		Fee:          c.TransferFee,
+		FeeRecipient: c.FeeRecipient,
+	}); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	return &chain.Result{Success: true, Units: unitsUsed}, nil
@@ -49,20 +131,55 @@ func (c *CreateAsset) Execute(
 func (c *CreateAsset) MaxUnits(chain.Rules) uint64 {
 	// We use size as the price of this transaction but we could just as easily
 	// use any other calculation.
-	return uint64(len(c.Metadata))
+	return uint64(len(c.Symbol)) + 1 + uint64(len(c.Name)) + uint64(len(c.URI)) + consts.IDLen +
+		uint64(len(c.Metadata)) + consts.Uint64Len*2 + 1 + crypto.PublicKeyLen
 }
 
 func (c *CreateAsset) Marshal(p *codec.Packer) {
+	p.PackBytes(c.Symbol)
+	p.PackByte(c.Decimals)
+	p.PackBytes(c.Name)
+	p.PackBytes(c.URI)
+	p.PackID(c.URIHash)
 	p.PackBytes(c.Metadata)
+	p.PackUint64(c.MaxSupply)
+	p.PackBool(c.Regulated)
+	p.PackUint64(c.TransferFee)
+	p.PackPublicKey(c.FeeRecipient)
 }
 
 func UnmarshalCreateAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
 	var create CreateAsset
+	p.UnpackBytes(MaxSymbolSize, true, &create.Symbol)
+	create.Decimals = p.UnpackByte()
+	p.UnpackBytes(MaxNameSize, true, &create.Name)
+	p.UnpackBytes(MaxURISize, false, &create.URI) // optional
+	p.UnpackID(false, &create.URIHash)            // empty when there is no uri
 	p.UnpackBytes(MaxMetadataSize, false, &create.Metadata)
+	create.MaxSupply = p.UnpackUint64(false) // 0 means no cap
+	create.Regulated = p.UnpackBool()
+	create.TransferFee = p.UnpackUint64(false)     // 0 means no fee
+	p.UnpackPublicKey(false, &create.FeeRecipient) // empty when there is no fee
 	return &create, p.Err()
 }
 
+// ValidSymbol returns true if [symbol] only contains uppercase letters and
+// digits.
+func ValidSymbol(symbol []byte) bool {
+	for _, c := range symbol {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
 func (*CreateAsset) ValidRange(chain.Rules) (int64, int64) {
 	// Returning -1, -1 means that the action is always valid.
 	return -1, -1
 }
+
+func (*CreateAsset) Spends() []*auth.Amount {
+	// Creating an asset doesn't spend any balance of the actor.
+	return nil
+}
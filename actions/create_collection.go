@@ -0,0 +1,76 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*CreateCollection)(nil)
+	_ auth.Spender = (*CreateCollection)(nil)
+)
+
+type CreateCollection struct {
+	// Metadata is creator-specified information about the collection.
+	Metadata []byte `json:"metadata"`
+}
+
+func (*CreateCollection) StateKeys(_ chain.Auth, txID ids.ID) [][]byte {
+	return [][]byte{storage.PrefixCollectionKey(txID)}
+}
+
+func (c *CreateCollection) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if len(c.Metadata) > MaxMetadataSize {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMetadataTooLarge}, nil
+	}
+	// It should only be possible to overwrite an existing collection if there
+	// is a hash collision.
+	if err := storage.SetCollection(ctx, db, txID, c.Metadata, actor, 0); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (c *CreateCollection) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return uint64(len(c.Metadata))
+}
+
+func (c *CreateCollection) Marshal(p *codec.Packer) {
+	p.PackBytes(c.Metadata)
+}
+
+func UnmarshalCreateCollection(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateCollection
+	p.UnpackBytes(MaxMetadataSize, false, &create.Metadata)
+	return &create, p.Err()
+}
+
+func (*CreateCollection) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*CreateCollection) Spends() []*auth.Amount {
+	// Creating a collection doesn't spend any balance of the actor.
+	return nil
+}
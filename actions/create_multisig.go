@@ -0,0 +1,119 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+	tutils "github.com/rafael-abuawad/samplevm/utils"
+)
+
+var (
+	_ chain.Action = (*CreateMultisig)(nil)
+	_ auth.Spender = (*CreateMultisig)(nil)
+)
+
+type CreateMultisig struct {
+	// Threshold is the number of [Signers] that must sign a transaction issued
+	// by the multisig account.
+	Threshold uint8 `json:"threshold"`
+
+	// Signers are the ED25519 public keys that can sign for the multisig
+	// account. They must be sorted in ascending order without duplicates, so
+	// that each set of signers maps to a single account.
+	Signers []crypto.PublicKey `json:"signers"`
+}
+
+func (c *CreateMultisig) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{storage.PrefixMultisigKey(c.Account())}
+}
+
+// Account returns the address of the multisig account registered by [c].
+func (c *CreateMultisig) Account() crypto.PublicKey {
+	return tutils.MultisigActor(c.Threshold, c.Signers)
+}
+
+func (c *CreateMultisig) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	_ chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if c.Threshold == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputThresholdZero}, nil
+	}
+	if int(c.Threshold) > len(c.Signers) {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputThresholdTooLarge}, nil
+	}
+	for i := 1; i < len(c.Signers); i++ {
+		if bytes.Compare(c.Signers[i-1][:], c.Signers[i][:]) >= 0 {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSignersNotSorted}, nil
+		}
+	}
+	account := c.Account()
+	exists, _, _, err := storage.GetMultisig(ctx, db, account)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMultisigExists}, nil
+	}
+	if err := storage.SetMultisig(ctx, db, account, c.Threshold, c.Signers); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (c *CreateMultisig) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return 1 + consts.IntLen + uint64(len(c.Signers))*crypto.PublicKeyLen
+}
+
+func (c *CreateMultisig) Marshal(p *codec.Packer) {
+	p.PackByte(c.Threshold)
+	p.PackInt(len(c.Signers))
+	for _, signer := range c.Signers {
+		p.PackPublicKey(signer)
+	}
+}
+
+func UnmarshalCreateMultisig(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateMultisig
+	create.Threshold = p.UnpackByte()
+	count := p.UnpackInt(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if count > auth.MaxMultisigSigners {
+		return nil, ErrTooManyEntries
+	}
+	create.Signers = make([]crypto.PublicKey, count)
+	for i := 0; i < count; i++ {
+		p.UnpackPublicKey(true, &create.Signers[i])
+	}
+	return &create, p.Err()
+}
+
+func (*CreateMultisig) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*CreateMultisig) Spends() []*auth.Amount {
+	// Creating a multisig doesn't spend any balance of the actor.
+	return nil
+}
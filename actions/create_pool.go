@@ -0,0 +1,112 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*CreatePool)(nil)
+	_ auth.Spender = (*CreatePool)(nil)
+)
+
+type CreatePool struct {
+	// AssetA and AssetB are the assets traded by the pool. Either can be the
+	// native asset.
+	AssetA ids.ID `json:"assetA"`
+	AssetB ids.ID `json:"assetB"`
+
+	// Fee is kept by the pool from every [Swap] (in basis points).
+	Fee uint64 `json:"fee"`
+
+	// Notes:
+	// * The shares of the pool are tracked by an asset with the same ID as the
+	//   pool ([TxID]), which can be transferred like any other asset.
+	// * Users are allowed to create any number of pools for the same pair.
+}
+
+func (c *CreatePool) StateKeys(_ chain.Auth, txID ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixAssetKey(c.AssetA),
+		storage.PrefixAssetKey(c.AssetB),
+		storage.PrefixAssetKey(txID),
+		storage.PrefixPoolKey(txID),
+	}
+}
+
+func (c *CreatePool) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	_ chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if c.AssetA == c.AssetB {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSameInOut}, nil
+	}
+	if c.Fee >= BasisPoints {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputFeeTooLarge}, nil
+	}
+	for _, asset := range []ids.ID{c.AssetA, c.AssetB} {
+		exists, _, err := storage.GetAsset(ctx, db, asset)
+		if err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if !exists {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+		}
+	}
+	// Shares can only be minted by [AddLiquidity], so the share asset has no
+	// owner.
+	if err := storage.SetAsset(ctx, db, txID, &storage.Asset{
+		Symbol:   []byte(PoolShareSymbol),
+		Metadata: PoolMetadata(c.AssetA, c.AssetB),
+	}); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetPool(ctx, db, txID, c.AssetA, c.AssetB, 0, 0, c.Fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CreatePool) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*2 + consts.Uint64Len
+}
+
+func (c *CreatePool) Marshal(p *codec.Packer) {
+	p.PackID(c.AssetA)
+	p.PackID(c.AssetB)
+	p.PackUint64(c.Fee)
+}
+
+func UnmarshalCreatePool(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreatePool
+	p.UnpackID(false, &create.AssetA)  // empty ID is the native asset
+	p.UnpackID(false, &create.AssetB)  // empty ID is the native asset
+	create.Fee = p.UnpackUint64(false) // 0 means no fee
+	return &create, p.Err()
+}
+
+func (*CreatePool) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*CreatePool) Spends() []*auth.Amount {
+	// Creating a pool doesn't spend any balance of the actor.
+	return nil
+}
@@ -0,0 +1,131 @@
+package actions
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*CreateStream)(nil)
+	_ auth.Spender = (*CreateStream)(nil)
+)
+
+type CreateStream struct {
+	// Recipient can withdraw from the stream as it accrues using
+	// [WithdrawStream].
+	Recipient crypto.PublicKey `json:"recipient"`
+
+	// Asset to stream. This can be the native asset.
+	Asset ids.ID `json:"asset"`
+
+	// Value is the amount of [Asset] escrowed for the stream.
+	Value uint64 `json:"value"`
+
+	// Rate is the amount of [Asset] that accrues to [Recipient] every second,
+	// starting at the timestamp of the block that includes this action. The
+	// stream ends once [Value] has accrued.
+	Rate uint64 `json:"rate"`
+}
+
+func (c *CreateStream) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixFrozenKey(c.Asset, actor),
+		storage.PrefixFrozenKey(c.Asset, c.Recipient),
+		storage.PrefixStreamKey(txID),
+	}
+}
+
+func (c *CreateStream) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, c); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if c.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if c.Rate == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputRateZero}, nil
+	}
+	if output := checkFrozen(ctx, db, c.Asset, actor, c.Recipient); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SubBalance(ctx, db, actor, c.Asset, c.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetStream(
+		ctx, db, txID, actor, c.Recipient, c.Asset,
+		c.Value, c.Rate, t, 0,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CreateStream) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen + consts.IDLen + consts.Uint64Len*2
+}
+
+func (c *CreateStream) Marshal(p *codec.Packer) {
+	p.PackPublicKey(c.Recipient)
+	p.PackID(c.Asset)
+	p.PackUint64(c.Value)
+	p.PackUint64(c.Rate)
+}
+
+func UnmarshalCreateStream(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateStream
+	p.UnpackPublicKey(true, &create.Recipient)
+	p.UnpackID(false, &create.Asset) // empty ID is the native asset
+	create.Value = p.UnpackUint64(true)
+	create.Rate = p.UnpackUint64(true)
+	return &create, p.Err()
+}
+
+func (*CreateStream) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+// StreamedAmount returns how much of [deposit] has accrued at [t] for a stream
+// that started at [start] with [rate].
+func StreamedAmount(deposit uint64, rate uint64, start int64, t int64) uint64 {
+	elapsed := t - start
+	if elapsed <= 0 {
+		return 0
+	}
+	// [rate] * [elapsed] may overflow a uint64, so we perform the
+	// multiplication with big integers.
+	streamed := new(big.Int).SetUint64(rate)
+	streamed.Mul(streamed, big.NewInt(elapsed))
+	if !streamed.IsUint64() || streamed.Uint64() > deposit {
+		return deposit
+	}
+	return streamed.Uint64()
+}
+
+func (c *CreateStream) Spends() []*auth.Amount {
+	return []*auth.Amount{{Asset: c.Asset, Value: c.Value}}
+}
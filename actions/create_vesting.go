@@ -0,0 +1,142 @@
+package actions
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*CreateVesting)(nil)
+	_ auth.Spender = (*CreateVesting)(nil)
+)
+
+type CreateVesting struct {
+	// Beneficiary can claim [Value] as it vests using [ClaimVested].
+	Beneficiary crypto.PublicKey `json:"beneficiary"`
+
+	// Asset to lock. This can be the native asset.
+	Asset ids.ID `json:"asset"`
+
+	// Value is the total amount of [Asset] that will vest.
+	Value uint64 `json:"value"`
+
+	// Start is the unix timestamp (in seconds) at which vesting begins.
+	Start int64 `json:"start"`
+
+	// Cliff is the number of seconds after [Start] before anything can be
+	// claimed. Once the cliff passes, everything that vested since [Start]
+	// becomes claimable at once.
+	Cliff int64 `json:"cliff"`
+
+	// Duration is the number of seconds after [Start] until [Value] has fully
+	// vested. [Value] vests linearly over [Duration].
+	Duration int64 `json:"duration"`
+}
+
+func (c *CreateVesting) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixBalanceKey(actor, c.Asset),
+		storage.PrefixFrozenKey(c.Asset, actor),
+		storage.PrefixFrozenKey(c.Asset, c.Beneficiary),
+		storage.PrefixVestingKey(txID),
+	}
+}
+
+func (c *CreateVesting) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := c.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, c); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if c.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if c.Start < 0 || c.Duration <= 0 || c.Cliff < 0 || c.Cliff > c.Duration {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInvalidSchedule}, nil
+	}
+	if output := checkFrozen(ctx, db, c.Asset, actor, c.Beneficiary); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SubBalance(ctx, db, actor, c.Asset, c.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetVesting(
+		ctx, db, txID, c.Beneficiary, c.Asset,
+		c.Value, 0, c.Start, c.Cliff, c.Duration,
+	); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*CreateVesting) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen + consts.IDLen + consts.Uint64Len*4
+}
+
+func (c *CreateVesting) Marshal(p *codec.Packer) {
+	p.PackPublicKey(c.Beneficiary)
+	p.PackID(c.Asset)
+	p.PackUint64(c.Value)
+	p.PackInt64(c.Start)
+	p.PackInt64(c.Cliff)
+	p.PackInt64(c.Duration)
+}
+
+func UnmarshalCreateVesting(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var create CreateVesting
+	p.UnpackPublicKey(true, &create.Beneficiary)
+	p.UnpackID(false, &create.Asset) // empty ID is the native asset
+	create.Value = p.UnpackUint64(true)
+	create.Start = p.UnpackInt64(false)
+	create.Cliff = p.UnpackInt64(false) // no cliff
+	create.Duration = p.UnpackInt64(true)
+	return &create, p.Err()
+}
+
+func (*CreateVesting) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+// VestedAmount returns how much of [total] has vested at [t] for a schedule
+// created with [start], [cliff], and [duration].
+func VestedAmount(total uint64, start int64, cliff int64, duration int64, t int64) uint64 {
+	elapsed := t - start
+	switch {
+	case elapsed < cliff:
+		return 0
+	case elapsed >= duration:
+		return total
+	}
+	// [total] * [elapsed] may overflow a uint64, so we perform the
+	// multiplication with big integers.
+	vested := new(big.Int).SetUint64(total)
+	vested.Mul(vested, big.NewInt(elapsed))
+	vested.Div(vested, big.NewInt(duration))
+	return vested.Uint64()
+}
+
+func (c *CreateVesting) Spends() []*auth.Amount {
+	return []*auth.Amount{{Asset: c.Asset, Value: c.Value}}
+}
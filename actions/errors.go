@@ -2,4 +2,10 @@ package actions
 
 import "errors"
 
-var ErrNoSwapToFill = errors.New("no swap to fill")
+var (
+	ErrNoSwapToFill   = errors.New("no swap to fill")
+	ErrTooManyEntries = errors.New("too many entries")
+	ErrProofTooLong   = errors.New("proof is too long")
+
+	ErrActionNotAllowed = errors.New("action not allowed in sequence")
+)
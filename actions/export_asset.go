@@ -0,0 +1,277 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*ExportAsset)(nil)
+	_ auth.Spender = (*ExportAsset)(nil)
+)
+
+type ExportAsset struct {
+	// To is the recipient of [Value] on [Destination].
+	To crypto.PublicKey `json:"to"`
+
+	// Asset to export to [Destination].
+	Asset ids.ID `json:"asset"`
+
+	// Value of [Asset] that [To] will receive on [Destination].
+	Value uint64 `json:"value"`
+
+	// Return is set to true when [Asset] is a warp asset that is being sent
+	// back to the chain where it was created.
+	Return bool `json:"return"`
+
+	// Reward is the amount of [Asset] to send the [Actor] that imports this
+	// message on [Destination].
+	Reward uint64 `json:"reward"`
+
+	// SwapIn, AssetOut, SwapOut, and SwapExpiry describe an optional swap that
+	// can be filled by the [Actor] that imports this message on
+	// [Destination]. See [WarpTransfer] for more information.
+	SwapIn     uint64 `json:"swapIn"`
+	AssetOut   ids.ID `json:"assetOut"`
+	SwapOut    uint64 `json:"swapOut"`
+	SwapExpiry int64  `json:"swapExpiry"`
+
+	// Destination is the chainID that will receive [Value].
+	Destination ids.ID `json:"destination"`
+}
+
+func (e *ExportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	if e.Return {
+		return [][]byte{
+			storage.PrefixAssetKey(e.Asset),
+			storage.PrefixBalanceKey(actor, e.Asset),
+			storage.PrefixFrozenKey(e.Asset, actor),
+		}
+	}
+	return [][]byte{
+		storage.PrefixAssetKey(e.Asset),
+		storage.PrefixLoanKey(e.Asset, e.Destination),
+		storage.PrefixBalanceKey(actor, e.Asset),
+		storage.PrefixFrozenKey(e.Asset, actor),
+	}
+}
+
+func (e *ExportAsset) executeReturn(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	actor crypto.PublicKey,
+	txID ids.ID,
+) (*chain.Result, error) {
+	unitsUsed := e.MaxUnits(r)
+	exists, asset, err := storage.GetAsset(ctx, db, e.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if !asset.Warp {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNotWarpAsset}, nil
+	}
+	allowedDestination := ids.ID(asset.Metadata[consts.IDLen:])
+	if e.Destination != allowedDestination {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongDestination}, nil
+	}
+	newSupply, err := smath.Sub(asset.Supply, e.Value)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	newSupply, err = smath.Sub(newSupply, e.Reward)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if newSupply > 0 {
+		asset.Supply = newSupply
+		if err := storage.SetAsset(ctx, db, e.Asset, asset); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	} else {
+		// Once all funds have been returned to the source chain, the warp asset
+		// no longer needs to be tracked.
+		if err := storage.DeleteAsset(ctx, db, e.Asset); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	if err := storage.SubBalance(ctx, db, actor, e.Asset, e.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if e.Reward > 0 {
+		if err := storage.SubBalance(ctx, db, actor, e.Asset, e.Reward); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	originalAsset := ids.ID(asset.Metadata[:consts.IDLen])
+	return e.result(unitsUsed, originalAsset, asset.Symbol, asset.Decimals, txID)
+}
+
+func (e *ExportAsset) executeLoan(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	actor crypto.PublicKey,
+	txID ids.ID,
+) (*chain.Result, error) {
+	unitsUsed := e.MaxUnits(r)
+	exists, asset, err := storage.GetAsset(ctx, db, e.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if asset.Warp {
+		// Only assets that were created on this chain can be loaned to another
+		// chain (warp assets must be returned to their source).
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
+	}
+	if err := storage.AddLoan(ctx, db, e.Asset, e.Destination, e.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SubBalance(ctx, db, actor, e.Asset, e.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if e.Reward > 0 {
+		if err := storage.AddLoan(ctx, db, e.Asset, e.Destination, e.Reward); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+		if err := storage.SubBalance(ctx, db, actor, e.Asset, e.Reward); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	return e.result(unitsUsed, e.Asset, asset.Symbol, asset.Decimals, txID)
+}
+
+// result generates the [WarpTransfer] that will be sent to [Destination].
+func (e *ExportAsset) result(
+	unitsUsed uint64,
+	asset ids.ID,
+	symbol []byte,
+	decimals uint8,
+	txID ids.ID,
+) (*chain.Result, error) {
+	wt := &WarpTransfer{
+		To:         e.To,
+		Asset:      asset,
+		Symbol:     symbol,
+		Decimals:   decimals,
+		Value:      e.Value,
+		Return:     e.Return,
+		Reward:     e.Reward,
+		SwapIn:     e.SwapIn,
+		AssetOut:   e.AssetOut,
+		SwapOut:    e.SwapOut,
+		SwapExpiry: e.SwapExpiry,
+		TxID:       txID,
+	}
+	payload, err := wt.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	wm := &warp.UnsignedMessage{
+		DestinationChainID: e.Destination,
+		// SourceChainID is populated by hypersdk
+		Payload: payload,
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, WarpMessage: wm}, nil
+}
+
+func (e *ExportAsset) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := e.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, e); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if e.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if output := checkFrozen(ctx, db, e.Asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if e.Return {
+		return e.executeReturn(ctx, r, db, actor, txID)
+	}
+	return e.executeLoan(ctx, r, db, actor, txID)
+}
+
+func (*ExportAsset) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen + consts.IDLen +
+		consts.Uint64Len + 1 + consts.Uint64Len*2 +
+		consts.IDLen + consts.Uint64Len*2 + consts.IDLen
+}
+
+func (e *ExportAsset) Marshal(p *codec.Packer) {
+	p.PackPublicKey(e.To)
+	p.PackID(e.Asset)
+	p.PackUint64(e.Value)
+	p.PackBool(e.Return)
+	p.PackUint64(e.Reward)
+	p.PackUint64(e.SwapIn)
+	p.PackID(e.AssetOut)
+	p.PackUint64(e.SwapOut)
+	p.PackInt64(e.SwapExpiry)
+	p.PackID(e.Destination)
+}
+
+func UnmarshalExportAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var export ExportAsset
+	p.UnpackPublicKey(false, &export.To) // can transfer to blackhole
+	p.UnpackID(false, &export.Asset)     // empty ID is the native asset
+	export.Value = p.UnpackUint64(true)
+	export.Return = p.UnpackBool()
+	export.Reward = p.UnpackUint64(false) // reward not required
+	export.SwapIn = p.UnpackUint64(false) // optional
+	p.UnpackID(false, &export.AssetOut)
+	export.SwapOut = p.UnpackUint64(false)
+	export.SwapExpiry = p.UnpackInt64(false)
+	p.UnpackID(true, &export.Destination)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !ValidSwapParams(
+		export.Value,
+		export.SwapIn,
+		export.AssetOut,
+		export.SwapOut,
+		export.SwapExpiry,
+	) {
+		return nil, chain.ErrInvalidObject
+	}
+	return &export, nil
+}
+
+func (*ExportAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (e *ExportAsset) Spends() []*auth.Amount {
+	return []*auth.Amount{{Asset: e.Asset, Value: e.Value}, {Asset: e.Asset, Value: e.Reward}}
+}
@@ -0,0 +1,81 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+// TransferFee returns the amount of [value] that is withheld when transferring
+// an asset with a transfer fee of [fee] basis points.
+func TransferFee(value uint64, fee uint64) uint64 {
+	// We split [value] to avoid overflowing when multiplying by [fee].
+	return value/BasisPoints*fee + value%BasisPoints*fee/BasisPoints
+}
+
+// withholdFee accrues the transfer fee of [asset] on [value] for its fee
+// recipient and returns the amount withheld.
+//
+// Any action that calls [withholdFee] must include [storage.PrefixAssetKey] and
+// [storage.PrefixFeesKey] for [asset] in its [StateKeys].
+func withholdFee(ctx context.Context, db chain.Database, asset ids.ID, value uint64) (uint64, error) {
+	exists, a, err := storage.GetAsset(ctx, db, asset)
+	if err != nil {
+		return 0, err
+	}
+	if !exists || a.Fee == 0 {
+		return 0, nil
+	}
+	withheld := TransferFee(value, a.Fee)
+	if withheld == 0 {
+		return 0, nil
+	}
+	if err := storage.AddFees(ctx, db, asset, withheld); err != nil {
+		return 0, err
+	}
+	return withheld, nil
+}
+
+// transferResult returns the successful result of an action that withheld
+// [fee]. Transfers that don't withhold a fee have no output.
+func transferResult(unitsUsed uint64, fee uint64) (*chain.Result, error) {
+	if fee == 0 {
+		return &chain.Result{Success: true, Units: unitsUsed}, nil
+	}
+	tr := &TransferResult{Fee: fee}
+	output, err := tr.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, Output: output}, nil
+}
+
+// TransferResult is the output of successful transfers that withheld a fee.
+// [Fee] is the amount that was withheld from the transferred value.
+type TransferResult struct {
+	Fee uint64 `json:"fee"`
+}
+
+func UnmarshalTransferResult(b []byte) (*TransferResult, error) {
+	p := codec.NewReader(b, consts.Uint64Len)
+	var result TransferResult
+	result.Fee = p.UnpackUint64(false)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &result, nil
+}
+
+func (t *TransferResult) Marshal() ([]byte, error) {
+	p := codec.NewWriter(consts.Uint64Len)
+	p.PackUint64(t.Fee)
+	return p.Bytes(), p.Err()
+}
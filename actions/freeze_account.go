@@ -0,0 +1,117 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var _ chain.Action = (*FreezeAccount)(nil)
+
+type FreezeAccount struct {
+	// Asset is the [TxID] that created the asset. It must have been created
+	// with [Regulated] set.
+	Asset ids.ID `json:"asset"`
+
+	// Account will not be able to send or receive [Asset] until it is
+	// unfrozen using [UnfreezeAccount].
+	Account crypto.PublicKey `json:"account"`
+}
+
+func (f *FreezeAccount) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixAssetKey(f.Asset),
+		storage.PrefixFrozenKey(f.Asset, f.Account),
+	}
+}
+
+func (f *FreezeAccount) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := f.MaxUnits(r) // max units == units
+	if output := checkRegulator(ctx, db, f.Asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SetFrozen(ctx, db, f.Asset, f.Account); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*FreezeAccount) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + crypto.PublicKeyLen
+}
+
+func (f *FreezeAccount) Marshal(p *codec.Packer) {
+	p.PackID(f.Asset)
+	p.PackPublicKey(f.Account)
+}
+
+func UnmarshalFreezeAccount(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var freeze FreezeAccount
+	p.UnpackID(true, &freeze.Asset) // the native asset is never regulated
+	p.UnpackPublicKey(false, &freeze.Account)
+	return &freeze, p.Err()
+}
+
+func (*FreezeAccount) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+// checkRegulator returns the output that should be returned if [actor] is not
+// allowed to freeze accounts or claw back funds for [asset], or nil if they
+// are.
+func checkRegulator(ctx context.Context, db chain.Database, asset ids.ID, actor crypto.PublicKey) []byte {
+	exists, a, err := storage.GetAsset(ctx, db, asset)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	if !exists {
+		return OutputAssetMissing
+	}
+	if !a.Regulated {
+		return OutputNotRegulated
+	}
+	if a.Owner != actor {
+		return OutputWrongOwner
+	}
+	return nil
+}
+
+// checkFrozen returns [OutputAccountFrozen] if any of [accounts] is frozen for
+// [asset], or nil if none of them are. Accounts can only be frozen for
+// regulated assets, so this never fails for any other asset.
+//
+// Any action that moves [asset] into or out of an account must include
+// [storage.PrefixFrozenKey] for that account in its [StateKeys].
+func checkFrozen(ctx context.Context, db chain.Database, asset ids.ID, accounts ...crypto.PublicKey) []byte {
+	for _, account := range accounts {
+		frozen, err := storage.GetFrozen(ctx, db, asset, account)
+		if err != nil {
+			return utils.ErrBytes(err)
+		}
+		if frozen {
+			return OutputAccountFrozen
+		}
+	}
+	return nil
+}
@@ -0,0 +1,281 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*ImportAsset)(nil)
+	_ auth.Spender = (*ImportAsset)(nil)
+)
+
+type ImportAsset struct {
+	// Fill indicates if the actor wishes to fill the order request in the warp
+	// message. This must be true if the warp message is in a block with
+	// a timestamp < [SwapExpiry].
+	Fill bool `json:"fill"`
+
+	// warpTransfer is parsed from the inner *warp.Message
+	warpTransfer *WarpTransfer
+
+	// warpMessage is the full *warp.Message parsed from [chain.Transaction]
+	warpMessage *warp.Message
+}
+
+func (i *ImportAsset) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	var (
+		keys    [][]byte
+		assetID ids.ID
+		actor   = auth.GetActor(rauth)
+	)
+	if i.warpTransfer.Return {
+		assetID = i.warpTransfer.Asset
+		keys = [][]byte{
+			storage.PrefixLoanKey(i.warpTransfer.Asset, i.warpMessage.SourceChainID),
+			storage.PrefixBalanceKey(i.warpTransfer.To, i.warpTransfer.Asset),
+		}
+	} else {
+		assetID = ImportedAssetID(i.warpTransfer.Asset, i.warpMessage.SourceChainID)
+		keys = [][]byte{
+			storage.PrefixAssetKey(assetID),
+			storage.PrefixBalanceKey(i.warpTransfer.To, assetID),
+		}
+	}
+
+	// If the [warpTransfer] specified a reward, we add the state key to make
+	// sure it is paid.
+	if i.warpTransfer.Reward > 0 {
+		keys = append(keys, storage.PrefixBalanceKey(actor, assetID))
+	}
+
+	// If the [warpTransfer] requests a swap, we add the state keys to transfer
+	// the required balances.
+	if i.Fill && i.warpTransfer.SwapIn > 0 {
+		keys = append(keys, storage.PrefixBalanceKey(actor, i.warpTransfer.AssetOut))
+		keys = append(keys, storage.PrefixBalanceKey(actor, assetID))
+		keys = append(keys, storage.PrefixBalanceKey(i.warpTransfer.To, i.warpTransfer.AssetOut))
+		keys = append(keys, storage.PrefixFrozenKey(assetID, i.warpTransfer.To))
+		keys = append(keys, storage.PrefixFrozenKey(assetID, actor))
+		keys = append(keys, storage.PrefixFrozenKey(i.warpTransfer.AssetOut, actor))
+		keys = append(keys, storage.PrefixFrozenKey(i.warpTransfer.AssetOut, i.warpTransfer.To))
+		keys = append(keys, storage.PrefixAssetKey(i.warpTransfer.AssetOut))
+		keys = append(keys, storage.PrefixFeesKey(i.warpTransfer.AssetOut))
+		keys = append(keys, storage.PrefixAssetKey(assetID))
+		keys = append(keys, storage.PrefixFeesKey(assetID))
+	}
+	return keys
+}
+
+func (i *ImportAsset) executeMint(
+	ctx context.Context,
+	db chain.Database,
+	actor crypto.PublicKey,
+) []byte {
+	asset := ImportedAssetID(i.warpTransfer.Asset, i.warpMessage.SourceChainID)
+	exists, a, err := storage.GetAsset(ctx, db, asset)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	if exists && !a.Warp {
+		// Should never happen
+		return OutputConflictingAsset
+	}
+	if !exists {
+		a = &storage.Asset{
+			Symbol:   i.warpTransfer.Symbol,
+			Decimals: i.warpTransfer.Decimals,
+			Metadata: ImportedAssetMetadata(i.warpTransfer.Asset, i.warpMessage.SourceChainID),
+			Warp:     true,
+		}
+	}
+	newSupply, err := smath.Add64(a.Supply, i.warpTransfer.Value)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	newSupply, err = smath.Add64(newSupply, i.warpTransfer.Reward)
+	if err != nil {
+		return utils.ErrBytes(err)
+	}
+	a.Supply = newSupply
+	if err := storage.SetAsset(ctx, db, asset, a); err != nil {
+		return utils.ErrBytes(err)
+	}
+	if err := storage.AddBalance(ctx, db, i.warpTransfer.To, asset, i.warpTransfer.Value); err != nil {
+		return utils.ErrBytes(err)
+	}
+	if i.warpTransfer.Reward > 0 {
+		if err := storage.AddBalance(ctx, db, actor, asset, i.warpTransfer.Reward); err != nil {
+			return utils.ErrBytes(err)
+		}
+	}
+	return nil
+}
+
+func (i *ImportAsset) executeReturn(
+	ctx context.Context,
+	db chain.Database,
+	actor crypto.PublicKey,
+) []byte {
+	if err := storage.SubLoan(
+		ctx, db, i.warpTransfer.Asset,
+		i.warpMessage.SourceChainID, i.warpTransfer.Value,
+	); err != nil {
+		return utils.ErrBytes(err)
+	}
+	if err := storage.AddBalance(
+		ctx, db, i.warpTransfer.To,
+		i.warpTransfer.Asset, i.warpTransfer.Value,
+	); err != nil {
+		return utils.ErrBytes(err)
+	}
+	if i.warpTransfer.Reward > 0 {
+		if err := storage.SubLoan(
+			ctx, db, i.warpTransfer.Asset,
+			i.warpMessage.SourceChainID, i.warpTransfer.Reward,
+		); err != nil {
+			return utils.ErrBytes(err)
+		}
+		if err := storage.AddBalance(
+			ctx, db, actor,
+			i.warpTransfer.Asset, i.warpTransfer.Reward,
+		); err != nil {
+			return utils.ErrBytes(err)
+		}
+	}
+	return nil
+}
+
+func (i *ImportAsset) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	warpVerified bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := i.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, i); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !warpVerified {
+		// The signature weight on the attached message did not satisfy the
+		// requirements returned by [Rules.GetWarpConfig].
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpVerificationFailed}, nil
+	}
+	if i.warpTransfer.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	var output []byte
+	if i.warpTransfer.Return {
+		output = i.executeReturn(ctx, db, actor)
+	} else {
+		output = i.executeMint(ctx, db, actor)
+	}
+	if len(output) > 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+
+	// Handle swap if present
+	if i.warpTransfer.SwapIn == 0 {
+		// We are ensured that [i.Fill] is false if [SwapIn] is 0 by the
+		// unmarshal code
+		return &chain.Result{Success: true, Units: unitsUsed}, nil
+	}
+	if !i.Fill {
+		if i.warpTransfer.SwapExpiry > t {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMustFill}, nil
+		}
+		return &chain.Result{Success: true, Units: unitsUsed}, nil
+	}
+	var assetIn ids.ID
+	if i.warpTransfer.Return {
+		assetIn = i.warpTransfer.Asset
+	} else {
+		assetIn = ImportedAssetID(i.warpTransfer.Asset, i.warpMessage.SourceChainID)
+	}
+	if output := checkFrozen(ctx, db, assetIn, i.warpTransfer.To, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if output := checkFrozen(ctx, db, i.warpTransfer.AssetOut, actor, i.warpTransfer.To); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SubBalance(ctx, db, i.warpTransfer.To, assetIn, i.warpTransfer.SwapIn); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	inFee, err := withholdFee(ctx, db, assetIn, i.warpTransfer.SwapIn)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, assetIn, i.warpTransfer.SwapIn-inFee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SubBalance(ctx, db, actor, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	outFee, err := withholdFee(ctx, db, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, i.warpTransfer.To, i.warpTransfer.AssetOut, i.warpTransfer.SwapOut-outFee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (i *ImportAsset) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return uint64(len(i.warpMessage.Payload)) + 1
+}
+
+// The [WarpTransfer] is not encoded here because it is included in the
+// *warp.Message attached to the transaction.
+func (i *ImportAsset) Marshal(p *codec.Packer) {
+	p.PackBool(i.Fill)
+}
+
+func UnmarshalImportAsset(p *codec.Packer, wm *warp.Message) (chain.Action, error) {
+	var (
+		imp ImportAsset
+		err error
+	)
+	imp.Fill = p.UnpackBool()
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	imp.warpMessage = wm
+	imp.warpTransfer, err = UnmarshalWarpTransfer(imp.warpMessage.Payload)
+	if err != nil {
+		return nil, err
+	}
+	// Ensure we can fill the swap if it exists
+	if imp.Fill && imp.warpTransfer.SwapIn == 0 {
+		return nil, ErrNoSwapToFill
+	}
+	return &imp, nil
+}
+
+func (*ImportAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (i *ImportAsset) Spends() []*auth.Amount {
+	if !i.Fill || i.warpTransfer.SwapIn == 0 {
+		return nil
+	}
+	return []*auth.Amount{{Asset: i.warpTransfer.AssetOut, Value: i.warpTransfer.SwapOut}}
+}
@@ -0,0 +1,114 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*LockHTLC)(nil)
+	_ auth.Spender = (*LockHTLC)(nil)
+)
+
+type LockHTLC struct {
+	// To is the recipient of [Value] if they reveal the preimage of [Hash]
+	// before [Expiry].
+	To crypto.PublicKey `json:"to"`
+
+	// Asset to lock.
+	Asset ids.ID `json:"asset"`
+
+	// Value is the amount of [Asset] to lock.
+	Value uint64 `json:"value"`
+
+	// Hash is the SHA-256 hash of the secret preimage.
+	Hash ids.ID `json:"hash"`
+
+	// Expiry is the unix timestamp (in seconds) at which the actor can
+	// reclaim [Value] using [RefundHTLC].
+	Expiry int64 `json:"expiry"`
+}
+
+func (l *LockHTLC) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixBalanceKey(actor, l.Asset),
+		storage.PrefixFrozenKey(l.Asset, actor),
+		storage.PrefixFrozenKey(l.Asset, l.To),
+		storage.PrefixHTLCKey(txID),
+	}
+}
+
+func (l *LockHTLC) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := l.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, l); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if l.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if l.Expiry <= t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputExpiryInPast}, nil
+	}
+	if output := checkFrozen(ctx, db, l.Asset, actor, l.To); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SubBalance(ctx, db, actor, l.Asset, l.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetHTLC(ctx, db, txID, actor, l.To, l.Asset, l.Value, l.Hash, l.Expiry); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*LockHTLC) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen + consts.IDLen*2 + consts.Uint64Len*2
+}
+
+func (l *LockHTLC) Marshal(p *codec.Packer) {
+	p.PackPublicKey(l.To)
+	p.PackID(l.Asset)
+	p.PackUint64(l.Value)
+	p.PackID(l.Hash)
+	p.PackInt64(l.Expiry)
+}
+
+func UnmarshalLockHTLC(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var lock LockHTLC
+	p.UnpackPublicKey(true, &lock.To)
+	p.UnpackID(false, &lock.Asset) // empty ID is the native asset
+	lock.Value = p.UnpackUint64(true)
+	p.UnpackID(true, &lock.Hash)
+	lock.Expiry = p.UnpackInt64(true)
+	return &lock, p.Err()
+}
+
+func (*LockHTLC) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (l *LockHTLC) Spends() []*auth.Amount {
+	return []*auth.Amount{{Asset: l.Asset, Value: l.Value}}
+}
@@ -33,6 +33,7 @@ func (m *MintAsset) StateKeys(chain.Auth, ids.ID) [][]byte {
 	return [][]byte{
 		storage.PrefixAssetKey(m.Asset),
 		storage.PrefixBalanceKey(m.To, m.Asset),
+		storage.PrefixFrozenKey(m.Asset, m.To),
 	}
 }
 
@@ -53,28 +54,35 @@ func (m *MintAsset) Execute(
 	if m.Value == 0 {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
 	}
-	exists, metadata, supply, owner, isWarp, err := storage.GetAsset(ctx, db, m.Asset)
+	exists, asset, err := storage.GetAsset(ctx, db, m.Asset)
 	if err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	if !exists {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
 	}
-	if isWarp {
+	if asset.Warp {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
 	}
-	if owner != actor {
+	if asset.Owner != actor {
 		return &chain.Result{
 			Success: false,
 			Units:   unitsUsed,
 			Output:  OutputWrongOwner,
 		}, nil
 	}
-	newSupply, err := smath.Add64(supply, m.Value)
+	if output := checkFrozen(ctx, db, m.Asset, m.To); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	newSupply, err := smath.Add64(asset.Supply, m.Value)
 	if err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	if err := storage.SetAsset(ctx, db, m.Asset, metadata, newSupply, actor, isWarp); err != nil {
+	if asset.MaxSupply > 0 && newSupply > asset.MaxSupply {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMaxSupplyExceeded}, nil
+	}
+	asset.Supply = newSupply
+	if err := storage.SetAsset(ctx, db, m.Asset, asset); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
 	if err := storage.AddBalance(ctx, db, m.To, m.Asset, m.Value); err != nil {
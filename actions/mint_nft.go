@@ -0,0 +1,121 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var _ chain.Action = (*MintNFT)(nil)
+
+type MintNFT struct {
+	// Collection is the [TxID] that created the collection. Only the owner
+	// of [Collection] can mint NFTs.
+	Collection ids.ID `json:"collection"`
+
+	// ID uniquely identifies the NFT within [Collection]. It can't be reused,
+	// even after the NFT is burned.
+	ID uint64 `json:"id"`
+
+	// To is the recipient of the NFT.
+	To crypto.PublicKey `json:"to"`
+
+	// Metadata is creator-specified information about the NFT.
+	Metadata []byte `json:"metadata"`
+}
+
+func (m *MintNFT) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixCollectionKey(m.Collection),
+		storage.PrefixNFTKey(m.Collection, m.ID),
+		storage.PrefixBurnedNFTKey(m.Collection, m.ID),
+	}
+}
+
+func (m *MintNFT) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := m.MaxUnits(r) // max units == units
+	if len(m.Metadata) > MaxMetadataSize {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMetadataTooLarge}, nil
+	}
+	exists, metadata, owner, supply, err := storage.GetCollection(ctx, db, m.Collection)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputCollectionMissing}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	exists, _, _, err = storage.GetNFT(ctx, db, m.Collection, m.ID)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNFTAlreadyExists}, nil
+	}
+	burned, err := storage.GetNFTBurned(ctx, db, m.Collection, m.ID)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if burned {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNFTBurned}, nil
+	}
+	newSupply, err := smath.Add64(supply, 1)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetCollection(ctx, db, m.Collection, metadata, owner, newSupply); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SetNFT(ctx, db, m.Collection, m.ID, m.To, m.Metadata); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (m *MintNFT) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + consts.Uint64Len + crypto.PublicKeyLen + uint64(len(m.Metadata))
+}
+
+func (m *MintNFT) Marshal(p *codec.Packer) {
+	p.PackID(m.Collection)
+	p.PackUint64(m.ID)
+	p.PackPublicKey(m.To)
+	p.PackBytes(m.Metadata)
+}
+
+func UnmarshalMintNFT(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var mint MintNFT
+	p.UnpackID(true, &mint.Collection)
+	mint.ID = p.UnpackUint64(false)
+	p.UnpackPublicKey(true, &mint.To)
+	p.UnpackBytes(MaxMetadataSize, false, &mint.Metadata)
+	return &mint, p.Err()
+}
+
+func (*MintNFT) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,91 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var _ chain.Action = (*ModifyAsset)(nil)
+
+type ModifyAsset struct {
+	// Asset is the [TxID] that created the asset.
+	Asset ids.ID `json:"asset"`
+
+	// Metadata replaces the existing metadata of [Asset].
+	Metadata []byte `json:"metadata"`
+}
+
+func (m *ModifyAsset) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{storage.PrefixAssetKey(m.Asset)}
+}
+
+func (m *ModifyAsset) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := m.MaxUnits(r) // max units == units
+	if m.Asset == ids.Empty {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetIsNative}, nil
+	}
+	if len(m.Metadata) > MaxMetadataSize {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMetadataTooLarge}, nil
+	}
+	exists, asset, err := storage.GetAsset(ctx, db, m.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if asset.Warp {
+		// The metadata of a warp asset is used to look up its source chain and
+		// source asset, so it can never be changed.
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
+	}
+	if asset.Owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	asset.Metadata = m.Metadata
+	if err := storage.SetAsset(ctx, db, m.Asset, asset); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (m *ModifyAsset) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + uint64(len(m.Metadata))
+}
+
+func (m *ModifyAsset) Marshal(p *codec.Packer) {
+	p.PackID(m.Asset)
+	p.PackBytes(m.Metadata)
+}
+
+func UnmarshalModifyAsset(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var modify ModifyAsset
+	p.UnpackID(true, &modify.Asset) // empty ID is the native asset
+	p.UnpackBytes(MaxMetadataSize, false, &modify.Metadata)
+	return &modify, p.Err()
+}
+
+func (*ModifyAsset) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
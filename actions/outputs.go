@@ -5,6 +5,7 @@ var (
 	OutputAssetIsNative          = []byte("cannot mint native asset")
 	OutputAssetAlreadyExists     = []byte("asset already exists")
 	OutputAssetMissing           = []byte("asset missing")
+	OutputOrderMissing           = []byte("order is missing")
 	OutputInTickZero             = []byte("in rate is zero")
 	OutputOutTickZero            = []byte("out rate is zero")
 	OutputSupplyZero             = []byte("supply is zero")
@@ -17,6 +18,15 @@ var (
 	OutputInsufficientOutput     = []byte("insufficient output")
 	OutputValueMisaligned        = []byte("value is misaligned")
 	OutputMetadataTooLarge       = []byte("metadata is too large")
+	OutputSymbolEmpty            = []byte("symbol is empty")
+	OutputSymbolTooLarge         = []byte("symbol is too large")
+	OutputSymbolInvalid          = []byte("symbol must be uppercase alphanumeric")
+	OutputNameEmpty              = []byte("name is empty")
+	OutputNameTooLarge           = []byte("name is too large")
+	OutputDecimalsTooLarge       = []byte("decimals are too large")
+	OutputURITooLarge            = []byte("uri is too large")
+	OutputURIHashEmpty           = []byte("uri hash is empty")
+	OutputURIEmpty               = []byte("uri is empty")
 	OutputSameInOut              = []byte("same asset used for in and out")
 	OutputConflictingAsset       = []byte("warp has same asset as another")
 	OutputAnycast                = []byte("anycast output")
@@ -25,4 +35,48 @@ var (
 	OutputWrongDestination       = []byte("wrong destination")
 	OutputMustFill               = []byte("must fill request")
 	OutputWarpVerificationFailed = []byte("warp verification failed")
+	OutputOwnerEmpty             = []byte("new owner is empty")
+	OutputRenounceWithOwner      = []byte("renounce cannot specify new owner")
+	OutputMaxSupplyExceeded      = []byte("max supply exceeded")
+	OutputNoEntries              = []byte("no entries")
+	OutputMemoTooLarge           = []byte("memo is too large")
+	OutputSelfApproval           = []byte("cannot approve self")
+	OutputHTLCMissing            = []byte("htlc is missing")
+	OutputHTLCExpired            = []byte("htlc has expired")
+	OutputHTLCNotExpired         = []byte("htlc has not expired")
+	OutputExpiryInPast           = []byte("expiry is in the past")
+	OutputWrongAsset             = []byte("wrong asset")
+	OutputWrongPreimage          = []byte("wrong preimage")
+	OutputVestingMissing         = []byte("vesting is missing")
+	OutputInvalidSchedule        = []byte("invalid vesting schedule")
+	OutputNothingVested          = []byte("nothing vested")
+	OutputNotRegulated           = []byte("asset is not regulated")
+	OutputAccountFrozen          = []byte("account is frozen")
+	OutputAccountNotFrozen       = []byte("account is not frozen")
+	OutputFeeTooLarge            = []byte("transfer fee is too large")
+	OutputFeeRecipientEmpty      = []byte("fee recipient is empty")
+	OutputNoFees                 = []byte("no fees to claim")
+	OutputCollectionMissing      = []byte("collection is missing")
+	OutputNFTMissing             = []byte("nft is missing")
+	OutputNFTAlreadyExists       = []byte("nft already exists")
+	OutputNFTBurned              = []byte("nft was burned")
+	OutputAirdropMissing         = []byte("airdrop is missing")
+	OutputAirdropExpired         = []byte("airdrop has expired")
+	OutputAirdropNotExpired      = []byte("airdrop has not expired")
+	OutputAlreadyClaimed         = []byte("already claimed")
+	OutputInvalidProof           = []byte("invalid proof")
+	OutputIndexOutOfRange        = []byte("index is out of range")
+	OutputWrongEntries           = []byte("wrong number of entries")
+	OutputPoolMissing            = []byte("pool is missing")
+	OutputInsufficientLiquidity  = []byte("insufficient liquidity")
+	OutputBelowMinimum           = []byte("output is below minimum")
+	OutputRateZero               = []byte("rate is zero")
+	OutputStreamMissing          = []byte("stream is missing")
+	OutputNothingStreamed        = []byte("nothing streamed")
+	OutputThresholdZero          = []byte("threshold is zero")
+	OutputThresholdTooLarge      = []byte("threshold exceeds number of signers")
+	OutputSignersNotSorted       = []byte("signers must be sorted and unique")
+	OutputMultisigExists         = []byte("multisig already exists")
+	OutputSessionKeyNotAllowed   = []byte("only ed25519 accounts can register session keys")
+	OutputDuplicateLimit         = []byte("duplicate spending limit")
 )
@@ -0,0 +1,123 @@
+package actions
+
+import (
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+)
+
+// PoolMetadata is the metadata of the asset that tracks the shares of a pool
+// between [assetA] and [assetB].
+func PoolMetadata(assetA ids.ID, assetB ids.ID) []byte {
+	return []byte("LP " + assetA.String() + "/" + assetB.String())
+}
+
+// mulDiv returns [a] * [b] / [c] without overflowing and false if the result
+// doesn't fit in a uint64.
+func mulDiv(a uint64, b uint64, c uint64) (uint64, bool) {
+	v := new(big.Int).SetUint64(a)
+	v.Mul(v, new(big.Int).SetUint64(b))
+	v.Div(v, new(big.Int).SetUint64(c))
+	if !v.IsUint64() {
+		return 0, false
+	}
+	return v.Uint64(), true
+}
+
+// SwapOutput returns the amount of the other asset in a pool that is received
+// when swapping [amountIn] into a pool with [reserveIn] and [reserveOut] and a
+// pool fee of [fee] basis points.
+//
+// The pool fee is kept in the pool (and so accrues to the holders of its
+// shares).
+func SwapOutput(reserveIn uint64, reserveOut uint64, amountIn uint64, fee uint64) uint64 {
+	if reserveIn == 0 || reserveOut == 0 {
+		return 0
+	}
+	in := new(big.Int).SetUint64(amountIn)
+	in.Mul(in, new(big.Int).SetUint64(BasisPoints-fee))
+	num := new(big.Int).Mul(in, new(big.Int).SetUint64(reserveOut))
+	den := new(big.Int).SetUint64(reserveIn)
+	den.Mul(den, big.NewInt(BasisPoints))
+	den.Add(den, in)
+	// The output is always less than [reserveOut], so it fits in a uint64.
+	return num.Div(num, den).Uint64()
+}
+
+// initialShares returns the number of shares created when [amountA] and
+// [amountB] are the first liquidity added to a pool. [MinimumLiquidity] of
+// these are never given to anyone.
+func initialShares(amountA uint64, amountB uint64) uint64 {
+	v := new(big.Int).SetUint64(amountA)
+	v.Mul(v, new(big.Int).SetUint64(amountB))
+	// The square root of the product of two uint64s fits in a uint64.
+	return v.Sqrt(v).Uint64()
+}
+
+// LiquidityResult is the output of a successful [AddLiquidity] or
+// [RemoveLiquidity].
+//
+// For [AddLiquidity], [AmountA] and [AmountB] are the amounts deposited (which
+// may be less than requested to match the ratio of the pool) and [Shares] is
+// the number of shares minted. For [RemoveLiquidity], [AmountA] and [AmountB]
+// are the amounts received (after any transfer fee) and [Shares] is the number
+// of shares burned.
+type LiquidityResult struct {
+	AmountA uint64 `json:"amountA"`
+	AmountB uint64 `json:"amountB"`
+	Shares  uint64 `json:"shares"`
+}
+
+func (l *LiquidityResult) Marshal() ([]byte, error) {
+	p := codec.NewWriter(consts.Uint64Len * 3)
+	p.PackUint64(l.AmountA)
+	p.PackUint64(l.AmountB)
+	p.PackUint64(l.Shares)
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalLiquidityResult(b []byte) (*LiquidityResult, error) {
+	p := codec.NewReader(b, consts.Uint64Len*3)
+	var result LiquidityResult
+	result.AmountA = p.UnpackUint64(false)
+	result.AmountB = p.UnpackUint64(false)
+	result.Shares = p.UnpackUint64(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &result, nil
+}
+
+// SwapResult is the output of a successful [Swap]. [AmountOut] is the amount
+// received (after any transfer fee) and [Fee] is the transfer fee withheld.
+type SwapResult struct {
+	AmountOut uint64 `json:"amountOut"`
+	Fee       uint64 `json:"fee"`
+}
+
+func (s *SwapResult) Marshal() ([]byte, error) {
+	p := codec.NewWriter(consts.Uint64Len * 2)
+	p.PackUint64(s.AmountOut)
+	p.PackUint64(s.Fee)
+	return p.Bytes(), p.Err()
+}
+
+func UnmarshalSwapResult(b []byte) (*SwapResult, error) {
+	p := codec.NewReader(b, consts.Uint64Len*2)
+	var result SwapResult
+	result.AmountOut = p.UnpackUint64(true)
+	result.Fee = p.UnpackUint64(false)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &result, nil
+}
@@ -0,0 +1,125 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*ReclaimAirdrop)(nil)
+	_ auth.Spender = (*ReclaimAirdrop)(nil)
+)
+
+type ReclaimAirdrop struct {
+	// Airdrop is the [TxID] of the [CreateAirdrop] to reclaim. The actor must
+	// be its creator.
+	Airdrop ids.ID `json:"airdrop"`
+
+	// Asset is the asset distributed by [Airdrop]. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+
+	// Entries is the number of entries of [Airdrop]. We need to provide this
+	// to populate [StateKeys] with the keys of its claims, which are deleted
+	// with it.
+	Entries uint64 `json:"entries"`
+}
+
+func (rc *ReclaimAirdrop) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return append([][]byte{
+		storage.PrefixAirdropKey(rc.Airdrop),
+		storage.PrefixBalanceKey(actor, rc.Asset),
+		storage.PrefixFrozenKey(rc.Asset, actor),
+	}, storage.PrefixAirdropClaimKeys(rc.Airdrop, rc.Entries)...)
+}
+
+func (rc *ReclaimAirdrop) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := rc.MaxUnits(r) // max units == units
+	exists, creator, asset, _, entries, remaining, expiry, err := storage.GetAirdrop(ctx, db, rc.Airdrop)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAirdropMissing}, nil
+	}
+	if creator != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if asset != rc.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if entries != rc.Entries {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongEntries}, nil
+	}
+	if t < expiry {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAirdropNotExpired}, nil
+	}
+	if output := checkFrozen(ctx, db, asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.DeleteAirdrop(ctx, db, rc.Airdrop); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.DeleteAirdropClaims(ctx, db, rc.Airdrop, entries); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, asset, remaining); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (rc *ReclaimAirdrop) MaxUnits(chain.Rules) uint64 {
+	// We use size (including the claims bitmap, which has a bit per entry) as
+	// the price of this transaction but we could just as easily use any other
+	// calculation.
+	return consts.IDLen*2 + consts.Uint64Len + rc.Entries/8
+}
+
+func (rc *ReclaimAirdrop) Marshal(p *codec.Packer) {
+	p.PackID(rc.Airdrop)
+	p.PackID(rc.Asset)
+	p.PackUint64(rc.Entries)
+}
+
+func UnmarshalReclaimAirdrop(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var reclaim ReclaimAirdrop
+	p.UnpackID(true, &reclaim.Airdrop)
+	p.UnpackID(false, &reclaim.Asset) // empty ID is the native asset
+	reclaim.Entries = p.UnpackUint64(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if reclaim.Entries > MaxAirdropEntries {
+		return nil, ErrTooManyEntries
+	}
+	return &reclaim, nil
+}
+
+func (*ReclaimAirdrop) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*ReclaimAirdrop) Spends() []*auth.Amount {
+	// The unclaimed funds are returned to the actor.
+	return nil
+}
@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*RefundHTLC)(nil)
+	_ auth.Spender = (*RefundHTLC)(nil)
+)
+
+type RefundHTLC struct {
+	// HTLC is the [TxID] of the [LockHTLC] to refund.
+	HTLC ids.ID `json:"htlc"`
+
+	// Asset is the asset locked in [HTLC]. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+}
+
+func (rf *RefundHTLC) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixHTLCKey(rf.HTLC),
+		storage.PrefixBalanceKey(actor, rf.Asset),
+		storage.PrefixFrozenKey(rf.Asset, actor),
+	}
+}
+
+func (rf *RefundHTLC) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := rf.MaxUnits(r) // max units == units
+	exists, sender, _, asset, value, _, expiry, err := storage.GetHTLC(ctx, db, rf.HTLC)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputHTLCMissing}, nil
+	}
+	if sender != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if asset != rf.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if t < expiry {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputHTLCNotExpired}, nil
+	}
+	if output := checkFrozen(ctx, db, asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.DeleteHTLC(ctx, db, rf.HTLC); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, asset, value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*RefundHTLC) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen * 2
+}
+
+func (rf *RefundHTLC) Marshal(p *codec.Packer) {
+	p.PackID(rf.HTLC)
+	p.PackID(rf.Asset)
+}
+
+func UnmarshalRefundHTLC(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var refund RefundHTLC
+	p.UnpackID(true, &refund.HTLC)
+	p.UnpackID(false, &refund.Asset) // empty ID is the native asset
+	return &refund, p.Err()
+}
+
+func (*RefundHTLC) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*RefundHTLC) Spends() []*auth.Amount {
+	// The locked funds are returned to the actor.
+	return nil
+}
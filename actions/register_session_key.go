@@ -0,0 +1,145 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*RegisterSessionKey)(nil)
+	_ auth.Spender = (*RegisterSessionKey)(nil)
+)
+
+type RegisterSessionKey struct {
+	// SessionKey is the ED25519 public key that can sign transactions on behalf
+	// of the actor with [auth.SessionKey].
+	SessionKey crypto.PublicKey `json:"sessionKey"`
+
+	// Expiry is the last timestamp at which [SessionKey] can be used.
+	Expiry int64 `json:"expiry"`
+
+	// Actions are the type IDs of the actions [SessionKey] can issue.
+	Actions []uint8 `json:"actions"`
+
+	// Limits are the maximum amounts of each asset [SessionKey] can spend.
+	// Assets without a limit can't be spent. Fees count towards the limit of
+	// the native asset.
+	//
+	// Registering a session key that already exists replaces its expiry,
+	// actions and limits, so it can be revoked by registering it without any
+	// [Actions].
+	Limits []*auth.Amount `json:"limits"`
+}
+
+func (r *RegisterSessionKey) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	return [][]byte{storage.PrefixSessionKeyKey(auth.GetActor(rauth), r.SessionKey)}
+}
+
+func (r *RegisterSessionKey) Execute(
+	ctx context.Context,
+	rules chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := r.MaxUnits(rules) // max units == units
+	if _, ok := rauth.(*auth.ED25519); !ok {
+		// Session keys (and other delegated signers) can't register session
+		// keys of their own.
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputSessionKeyNotAllowed}, nil
+	}
+	if r.Expiry < t {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputExpiryInPast}, nil
+	}
+	assets := make([]ids.ID, len(r.Limits))
+	remaining := make([]uint64, len(r.Limits))
+	for i, limit := range r.Limits {
+		for _, asset := range assets[:i] {
+			if asset == limit.Asset {
+				return &chain.Result{Success: false, Units: unitsUsed, Output: OutputDuplicateLimit}, nil
+			}
+		}
+		assets[i] = limit.Asset
+		remaining[i] = limit.Value
+	}
+	if err := storage.SetSessionKey(ctx, db, actor, r.SessionKey, r.Expiry, r.Actions, assets, remaining); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (r *RegisterSessionKey) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen + consts.Uint64Len + consts.IntLen*2 +
+		uint64(len(r.Actions)) + uint64(len(r.Limits))*(consts.IDLen+consts.Uint64Len)
+}
+
+func (r *RegisterSessionKey) Marshal(p *codec.Packer) {
+	p.PackPublicKey(r.SessionKey)
+	p.PackInt64(r.Expiry)
+	p.PackInt(len(r.Actions))
+	for _, action := range r.Actions {
+		p.PackByte(action)
+	}
+	p.PackInt(len(r.Limits))
+	for _, limit := range r.Limits {
+		p.PackID(limit.Asset)
+		p.PackUint64(limit.Value)
+	}
+}
+
+func UnmarshalRegisterSessionKey(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var register RegisterSessionKey
+	p.UnpackPublicKey(true, &register.SessionKey)
+	register.Expiry = p.UnpackInt64(true)
+	actions := p.UnpackInt(false)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if actions > auth.MaxSessionKeyActions {
+		return nil, ErrTooManyEntries
+	}
+	register.Actions = make([]uint8, actions)
+	for i := 0; i < actions; i++ {
+		register.Actions[i] = p.UnpackByte()
+	}
+	limits := p.UnpackInt(false)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if limits > auth.MaxSessionKeyLimits {
+		return nil, ErrTooManyEntries
+	}
+	register.Limits = make([]*auth.Amount, limits)
+	for i := 0; i < limits; i++ {
+		var limit auth.Amount
+		p.UnpackID(false, &limit.Asset) // empty ID is the native asset
+		limit.Value = p.UnpackUint64(false)
+		register.Limits[i] = &limit
+	}
+	return &register, p.Err()
+}
+
+func (*RegisterSessionKey) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*RegisterSessionKey) Spends() []*auth.Amount {
+	// Registering a session key doesn't spend any balance of the actor.
+	return nil
+}
@@ -0,0 +1,180 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*RemoveLiquidity)(nil)
+	_ auth.Spender = (*RemoveLiquidity)(nil)
+)
+
+type RemoveLiquidity struct {
+	// Pool is the [TxID] of the [CreatePool].
+	Pool ids.ID `json:"pool"`
+
+	// AssetA and AssetB are the assets of [Pool] (in the same order). We need
+	// to provide these to populate [StateKeys].
+	AssetA ids.ID `json:"assetA"`
+	AssetB ids.ID `json:"assetB"`
+
+	// Shares is the number of shares of [Pool] to burn in exchange for a
+	// proportional amount of its reserves.
+	Shares uint64 `json:"shares"`
+
+	// MinA and MinB are the least the actor is willing to receive (after any
+	// transfer fee).
+	MinA uint64 `json:"minA"`
+	MinB uint64 `json:"minB"`
+}
+
+func (rl *RemoveLiquidity) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixPoolKey(rl.Pool),
+		storage.PrefixAssetKey(rl.Pool),
+		storage.PrefixBalanceKey(actor, rl.Pool),
+		storage.PrefixBalanceKey(actor, rl.AssetA),
+		storage.PrefixBalanceKey(actor, rl.AssetB),
+		storage.PrefixFrozenKey(rl.AssetA, actor),
+		storage.PrefixFrozenKey(rl.AssetB, actor),
+		storage.PrefixAssetKey(rl.AssetA),
+		storage.PrefixAssetKey(rl.AssetB),
+		storage.PrefixFeesKey(rl.AssetA),
+		storage.PrefixFeesKey(rl.AssetB),
+	}
+}
+
+func (rl *RemoveLiquidity) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := rl.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, rl); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if rl.Shares == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	exists, assetA, assetB, reserveA, reserveB, fee, err := storage.GetPool(ctx, db, rl.Pool)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputPoolMissing}, nil
+	}
+	if assetA != rl.AssetA || assetB != rl.AssetB {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if output := checkFrozen(ctx, db, assetA, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if output := checkFrozen(ctx, db, assetB, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	exists, shareAsset, err := storage.GetAsset(ctx, db, rl.Pool)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+
+	// Burn shares
+	//
+	// The actor can't hold more shares than [supply], so [amountA] and
+	// [amountB] are always less than the reserves.
+	if err := storage.SubBalance(ctx, db, actor, rl.Pool, rl.Shares); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	supply := shareAsset.Supply
+	shareAsset.Supply -= rl.Shares
+	if err := storage.SetAsset(ctx, db, rl.Pool, shareAsset); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	amountA, _ := mulDiv(rl.Shares, reserveA, supply)
+	amountB, _ := mulDiv(rl.Shares, reserveB, supply)
+	if amountA == 0 || amountB == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInsufficientLiquidity}, nil
+	}
+	if err := storage.SetPool(ctx, db, rl.Pool, assetA, assetB, reserveA-amountA, reserveB-amountB, fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+
+	// Pay out reserves
+	feeA, err := withholdFee(ctx, db, assetA, amountA)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	feeB, err := withholdFee(ctx, db, assetB, amountB)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	receivedA, receivedB := amountA-feeA, amountB-feeB
+	if receivedA < rl.MinA || receivedB < rl.MinB {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputBelowMinimum}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, assetA, receivedA); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, assetB, receivedB); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	lr := &LiquidityResult{AmountA: receivedA, AmountB: receivedB, Shares: rl.Shares}
+	output, err := lr.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, Output: output}, nil
+}
+
+func (*RemoveLiquidity) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*3 + consts.Uint64Len*3
+}
+
+func (rl *RemoveLiquidity) Marshal(p *codec.Packer) {
+	p.PackID(rl.Pool)
+	p.PackID(rl.AssetA)
+	p.PackID(rl.AssetB)
+	p.PackUint64(rl.Shares)
+	p.PackUint64(rl.MinA)
+	p.PackUint64(rl.MinB)
+}
+
+func UnmarshalRemoveLiquidity(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var remove RemoveLiquidity
+	p.UnpackID(true, &remove.Pool)
+	p.UnpackID(false, &remove.AssetA) // empty ID is the native asset
+	p.UnpackID(false, &remove.AssetB) // empty ID is the native asset
+	remove.Shares = p.UnpackUint64(true)
+	remove.MinA = p.UnpackUint64(false)
+	remove.MinB = p.UnpackUint64(false)
+	return &remove, p.Err()
+}
+
+func (*RemoveLiquidity) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (rl *RemoveLiquidity) Spends() []*auth.Amount {
+	// Shares are tracked as a balance of [Pool].
+	return []*auth.Amount{{Asset: rl.Pool, Value: rl.Shares}}
+}
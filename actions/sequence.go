@@ -0,0 +1,284 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"reflect"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	tconsts "github.com/rafael-abuawad/samplevm/consts"
+)
+
+var (
+	_ chain.Action   = (*Sequence)(nil)
+	_ auth.Composite = (*Sequence)(nil)
+)
+
+type Sequence struct {
+	// Actions are executed in order by the same actor. If any of them fails,
+	// the state changes of all of them are rolled back.
+	//
+	// Each action is executed with the ID returned by [SequenceStepID]
+	// instead of the ID of the transaction, so objects created by an action
+	// (assets, orders, ...) are identified by their step ID. Because that ID
+	// is not known until the transaction is signed, an action references an
+	// object created by an earlier step with [SequenceStepRef] instead.
+	Actions []chain.Action `json:"actions"`
+}
+
+// sequenceStepRefPrefix starts every ID returned by [SequenceStepRef]. The
+// remaining bytes of the ID are the step.
+var sequenceStepRefPrefix = bytes.Repeat([]byte{0xff}, consts.IDLen-consts.IntLen)
+
+// SequenceStepRef returns a placeholder for the ID of the object created by
+// the action at [step] of a [Sequence]. It can be used in place of any ID of
+// a later action, and is replaced by the [SequenceStepID] of [step] before
+// that action is executed.
+func SequenceStepRef(step int) ids.ID {
+	var id ids.ID
+	copy(id[:], sequenceStepRefPrefix)
+	binary.BigEndian.PutUint32(id[len(sequenceStepRefPrefix):], uint32(step))
+	return id
+}
+
+// SequenceStepID returns the ID that the action at [step] of a [Sequence]
+// issued in [txID] is executed with.
+func SequenceStepID(txID ids.ID, step int) ids.ID {
+	b := make([]byte, consts.IDLen+consts.IntLen)
+	copy(b, txID[:])
+	binary.BigEndian.PutUint32(b[consts.IDLen:], uint32(step))
+	return utils.ToID(b)
+}
+
+// Step returns the action at [step] of [s] issued in [txID], with every
+// [SequenceStepRef] of an earlier step replaced by its [SequenceStepID]. The
+// action in [Actions] is left unchanged.
+func (s *Sequence) Step(txID ids.ID, step int) chain.Action {
+	v := reflect.ValueOf(s.Actions[step])
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return s.Actions[step]
+	}
+	resolved := reflect.New(v.Elem().Type())
+	resolved.Elem().Set(v.Elem())
+	resolveStepRefs(resolved.Elem(), txID, step)
+	return resolved.Interface().(chain.Action)
+}
+
+var idType = reflect.TypeOf(ids.ID{})
+
+// resolveStepRefs replaces the references to steps before [step] in [v],
+// copying any slice or struct it points to before modifying it.
+func resolveStepRefs(v reflect.Value, txID ids.ID, step int) {
+	switch {
+	case v.Type() == idType:
+		id := v.Interface().(ids.ID)
+		if !bytes.HasPrefix(id[:], sequenceStepRefPrefix) {
+			return
+		}
+		ref := int(binary.BigEndian.Uint32(id[len(sequenceStepRefPrefix):]))
+		if ref < step {
+			v.Set(reflect.ValueOf(SequenceStepID(txID, ref)))
+		}
+	case v.Kind() == reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if f := v.Field(i); f.CanSet() {
+				resolveStepRefs(f, txID, step)
+			}
+		}
+	case v.Kind() == reflect.Pointer && !v.IsNil() && v.Elem().Kind() == reflect.Struct:
+		c := reflect.New(v.Elem().Type())
+		c.Elem().Set(v.Elem())
+		resolveStepRefs(c.Elem(), txID, step)
+		v.Set(c)
+	case v.Kind() == reflect.Slice && !v.IsNil():
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(c, v)
+		for i := 0; i < c.Len(); i++ {
+			resolveStepRefs(c.Index(i), txID, step)
+		}
+		v.Set(c)
+	}
+}
+
+func (s *Sequence) StateKeys(rauth chain.Auth, txID ids.ID) [][]byte {
+	var (
+		keys = [][]byte{}
+		seen = set.NewSet[string](len(s.Actions) * 4)
+	)
+	for i := range s.Actions {
+		for _, k := range s.Step(txID, i).StateKeys(rauth, SequenceStepID(txID, i)) {
+			if seen.Contains(string(k)) {
+				continue
+			}
+			seen.Add(string(k))
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (s *Sequence) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	txID ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	unitsUsed := s.MaxUnits(r) // max units == units
+	if len(s.Actions) == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNoEntries}, nil
+	}
+	sr := &SequenceResult{Outputs: make([][]byte, 0, len(s.Actions))}
+	for i := range s.Actions {
+		result, err := s.Step(txID, i).Execute(ctx, r, db, t, rauth, SequenceStepID(txID, i), false)
+		if err != nil {
+			return nil, err
+		}
+		sr.Outputs = append(sr.Outputs, result.Output)
+		if !result.Success {
+			// The outputs of the steps that already succeeded are reported
+			// alongside the output of the failed step, which is always the
+			// last one. Returning a failed result rolls back every step.
+			output, err := sr.Marshal()
+			if err != nil {
+				return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+			}
+			return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+		}
+	}
+	output, err := sr.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, Output: output}, nil
+}
+
+// Inner returns the actions executed by [s].
+func (s *Sequence) Inner() []chain.Action {
+	return s.Actions
+}
+
+func (s *Sequence) MaxUnits(r chain.Rules) uint64 {
+	// We use the units of each action as the price of this transaction but we
+	// could just as easily use any other calculation.
+	var units uint64
+	for _, action := range s.Actions {
+		units += action.MaxUnits(r)
+	}
+	return units
+}
+
+func (s *Sequence) Marshal(p *codec.Packer) {
+	p.PackInt(len(s.Actions))
+	for _, action := range s.Actions {
+		// Every action that can be unmarshaled is registered.
+		typeID, _, _, _ := tconsts.ActionRegistry.LookupType(action)
+		p.PackByte(typeID)
+		action.Marshal(p)
+	}
+}
+
+func UnmarshalSequence(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var sequence Sequence
+	count := p.UnpackInt(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if count > MaxSequenceActions {
+		return nil, ErrTooManyEntries
+	}
+	sequence.Actions = make([]chain.Action, count)
+	for i := 0; i < count; i++ {
+		typeID := p.UnpackByte()
+		unmarshal, isWarp, ok := tconsts.ActionRegistry.LookupIndex(typeID)
+		if !ok {
+			return nil, chain.ErrInvalidObject
+		}
+		// Warp messages are attached to the transaction rather than to an
+		// action, so actions that consume them cannot be sequenced.
+		if isWarp {
+			return nil, ErrActionNotAllowed
+		}
+		action, err := unmarshal(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		switch action.(type) {
+		case *ExportAsset, *Sequence:
+			// Only a single warp message can be emitted by a transaction and
+			// sequences cannot be nested.
+			return nil, ErrActionNotAllowed
+		}
+		sequence.Actions[i] = action
+	}
+	return &sequence, p.Err()
+}
+
+func (s *Sequence) ValidRange(r chain.Rules) (int64, int64) {
+	// A [Sequence] is only valid when all of its actions are valid. -1 means
+	// that a bound does not apply.
+	start, end := int64(-1), int64(-1)
+	for _, action := range s.Actions {
+		as, ae := action.ValidRange(r)
+		if as >= 0 && (start < 0 || as > start) {
+			start = as
+		}
+		if ae >= 0 && (end < 0 || ae < end) {
+			end = ae
+		}
+	}
+	return start, end
+}
+
+// SequenceResult is the output of a [Sequence]. [Outputs] contains the output
+// of each executed action, in order. If the [Sequence] failed, the last entry
+// is the output of the action that failed.
+type SequenceResult struct {
+	Outputs [][]byte `json:"outputs"`
+}
+
+func UnmarshalSequenceResult(b []byte) (*SequenceResult, error) {
+	p := codec.NewReader(b, consts.MaxInt)
+	var result SequenceResult
+	count := p.UnpackInt(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if count > MaxSequenceActions {
+		return nil, ErrTooManyEntries
+	}
+	result.Outputs = make([][]byte, count)
+	for i := 0; i < count; i++ {
+		p.UnpackBytes(-1, false, &result.Outputs[i])
+	}
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if !p.Empty() {
+		return nil, chain.ErrInvalidObject
+	}
+	return &result, nil
+}
+
+func (s *SequenceResult) Marshal() ([]byte, error) {
+	size := consts.IntLen
+	for _, output := range s.Outputs {
+		size += consts.IntLen + len(output)
+	}
+	p := codec.NewWriter(size)
+	p.PackInt(len(s.Outputs))
+	for _, output := range s.Outputs {
+		p.PackBytes(output)
+	}
+	return p.Bytes(), p.Err()
+}
@@ -0,0 +1,168 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*Swap)(nil)
+	_ auth.Spender = (*Swap)(nil)
+)
+
+type Swap struct {
+	// Pool is the [TxID] of the [CreatePool].
+	Pool ids.ID `json:"pool"`
+
+	// In is the asset of [Pool] the actor provides and [Out] is the asset of
+	// [Pool] the actor receives.
+	In  ids.ID `json:"in"`
+	Out ids.ID `json:"out"`
+
+	// Value is the amount of [In] to swap.
+	Value uint64 `json:"value"`
+
+	// MinOut is the least amount of [Out] the actor is willing to receive
+	// (after any transfer fee).
+	MinOut uint64 `json:"minOut"`
+}
+
+func (s *Swap) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixPoolKey(s.Pool),
+		storage.PrefixBalanceKey(actor, s.In),
+		storage.PrefixBalanceKey(actor, s.Out),
+		storage.PrefixFrozenKey(s.In, actor),
+		storage.PrefixFrozenKey(s.Out, actor),
+		storage.PrefixAssetKey(s.Out),
+		storage.PrefixFeesKey(s.Out),
+	}
+}
+
+func (s *Swap) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := s.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, s); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if s.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	exists, assetA, assetB, reserveA, reserveB, fee, err := storage.GetPool(ctx, db, s.Pool)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputPoolMissing}, nil
+	}
+	var reserveIn, reserveOut uint64
+	switch {
+	case s.In == assetA && s.Out == assetB:
+		reserveIn, reserveOut = reserveA, reserveB
+	case s.In == assetB && s.Out == assetA:
+		reserveIn, reserveOut = reserveB, reserveA
+	default:
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if output := checkFrozen(ctx, db, s.In, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if output := checkFrozen(ctx, db, s.Out, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if reserveIn == 0 || reserveOut == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInsufficientLiquidity}, nil
+	}
+	amountOut := SwapOutput(reserveIn, reserveOut, s.Value, fee)
+	if amountOut == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputInsufficientOutput}, nil
+	}
+
+	// Update pool
+	newReserveIn, err := smath.Add64(reserveIn, s.Value)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	newReserveOut := reserveOut - amountOut
+	if s.In == assetA {
+		reserveA, reserveB = newReserveIn, newReserveOut
+	} else {
+		reserveA, reserveB = newReserveOut, newReserveIn
+	}
+	if err := storage.SetPool(ctx, db, s.Pool, assetA, assetB, reserveA, reserveB, fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+
+	// Update balances
+	if err := storage.SubBalance(ctx, db, actor, s.In, s.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	transferFee, err := withholdFee(ctx, db, s.Out, amountOut)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	received := amountOut - transferFee
+	if received < s.MinOut {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputBelowMinimum}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, s.Out, received); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	sr := &SwapResult{AmountOut: received, Fee: transferFee}
+	output, err := sr.Marshal()
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed, Output: output}, nil
+}
+
+func (*Swap) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen*3 + consts.Uint64Len*2
+}
+
+func (s *Swap) Marshal(p *codec.Packer) {
+	p.PackID(s.Pool)
+	p.PackID(s.In)
+	p.PackID(s.Out)
+	p.PackUint64(s.Value)
+	p.PackUint64(s.MinOut)
+}
+
+func UnmarshalSwap(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var swap Swap
+	p.UnpackID(true, &swap.Pool)
+	p.UnpackID(false, &swap.In)  // empty ID is the native asset
+	p.UnpackID(false, &swap.Out) // empty ID is the native asset
+	swap.Value = p.UnpackUint64(true)
+	swap.MinOut = p.UnpackUint64(false)
+	return &swap, p.Err()
+}
+
+func (*Swap) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (s *Swap) Spends() []*auth.Amount {
+	return []*auth.Amount{{Asset: s.In, Value: s.Value}}
+}
@@ -14,7 +14,10 @@ import (
 	"github.com/rafael-abuawad/samplevm/storage"
 )
 
-var _ chain.Action = (*Transfer)(nil)
+var (
+	_ chain.Action = (*Transfer)(nil)
+	_ auth.Spender = (*Transfer)(nil)
+)
 
 type Transfer struct {
 	// To is the recipient of the [Value].
@@ -28,9 +31,14 @@ type Transfer struct {
 }
 
 func (t *Transfer) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
 	return [][]byte{
-		storage.PrefixBalanceKey(auth.GetActor(rauth), t.Asset),
+		storage.PrefixBalanceKey(actor, t.Asset),
 		storage.PrefixBalanceKey(t.To, t.Asset),
+		storage.PrefixFrozenKey(t.Asset, actor),
+		storage.PrefixFrozenKey(t.Asset, t.To),
+		storage.PrefixAssetKey(t.Asset),
+		storage.PrefixFeesKey(t.Asset),
 	}
 }
 
@@ -45,16 +53,26 @@ func (t *Transfer) Execute(
 ) (*chain.Result, error) {
 	actor := auth.GetActor(rauth)
 	unitsUsed := t.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, t); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
 	if t.Value == 0 {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
 	}
+	if output := checkFrozen(ctx, db, t.Asset, actor, t.To); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
 	if err := storage.SubBalance(ctx, db, actor, t.Asset, t.Value); err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	if err := storage.AddBalance(ctx, db, t.To, t.Asset, t.Value); err != nil {
+	fee, err := withholdFee(ctx, db, t.Asset, t.Value)
+	if err != nil {
 		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
 	}
-	return &chain.Result{Success: true, Units: unitsUsed}, nil
+	if err := storage.AddBalance(ctx, db, t.To, t.Asset, t.Value-fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return transferResult(unitsUsed, fee)
 }
 
 func (*Transfer) MaxUnits(chain.Rules) uint64 {
@@ -81,3 +99,7 @@ func (*Transfer) ValidRange(chain.Rules) (int64, int64) {
 	// Returning -1, -1 means that the action is always valid.
 	return -1, -1
 }
+
+func (t *Transfer) Spends() []*auth.Amount {
+	return []*auth.Amount{{Asset: t.Asset, Value: t.Value}}
+}
@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var _ chain.Action = (*TransferAssetOwnership)(nil)
+
+type TransferAssetOwnership struct {
+	// Asset is the [TxID] that created the asset.
+	Asset ids.ID `json:"asset"`
+
+	// To is the recipient of the minting rights of [Asset].
+	To crypto.PublicKey `json:"to"`
+
+	// Renounce permanently removes the owner of [Asset] (setting it to
+	// [crypto.EmptyPublicKey]), which fixes its supply forever.
+	//
+	// [To] must be empty if [Renounce] is true.
+	Renounce bool `json:"renounce"`
+}
+
+func (t *TransferAssetOwnership) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{storage.PrefixAssetKey(t.Asset)}
+}
+
+func (t *TransferAssetOwnership) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := t.MaxUnits(r) // max units == units
+	if t.Asset == ids.Empty {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetIsNative}, nil
+	}
+	if t.Renounce && t.To != crypto.EmptyPublicKey {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputRenounceWithOwner}, nil
+	}
+	if !t.Renounce && t.To == crypto.EmptyPublicKey {
+		// Ownership can only be given up explicitly using [Renounce].
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputOwnerEmpty}, nil
+	}
+	exists, asset, err := storage.GetAsset(ctx, db, t.Asset)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAssetMissing}, nil
+	}
+	if asset.Warp {
+		// Warp assets are only minted by [ImportAsset], so they never have an
+		// owner.
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWarpAsset}, nil
+	}
+	if asset.Owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	asset.Owner = t.To
+	if err := storage.SetAsset(ctx, db, t.Asset, asset); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*TransferAssetOwnership) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + crypto.PublicKeyLen + 1
+}
+
+func (t *TransferAssetOwnership) Marshal(p *codec.Packer) {
+	p.PackID(t.Asset)
+	p.PackPublicKey(t.To)
+	p.PackBool(t.Renounce)
+}
+
+func UnmarshalTransferAssetOwnership(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var transfer TransferAssetOwnership
+	p.UnpackID(true, &transfer.Asset)      // empty ID is the native asset
+	p.UnpackPublicKey(false, &transfer.To) // empty when renouncing
+	transfer.Renounce = p.UnpackBool()
+	return &transfer, p.Err()
+}
+
+func (*TransferAssetOwnership) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,104 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var _ chain.Action = (*TransferFrom)(nil)
+
+type TransferFrom struct {
+	// Owner is the account whose funds are moved. The actor must have been
+	// approved by [Owner] using [Approve].
+	Owner crypto.PublicKey `json:"owner"`
+
+	// To is the recipient of the [Value].
+	To crypto.PublicKey `json:"to"`
+
+	// Asset to transfer to [To].
+	Asset ids.ID `json:"asset"`
+
+	// Amount are transferred to [To].
+	Value uint64 `json:"value"`
+}
+
+func (t *TransferFrom) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixAllowanceKey(t.Owner, auth.GetActor(rauth), t.Asset),
+		storage.PrefixBalanceKey(t.Owner, t.Asset),
+		storage.PrefixBalanceKey(t.To, t.Asset),
+		storage.PrefixFrozenKey(t.Asset, t.Owner),
+		storage.PrefixFrozenKey(t.Asset, t.To),
+		storage.PrefixAssetKey(t.Asset),
+		storage.PrefixFeesKey(t.Asset),
+	}
+}
+
+func (t *TransferFrom) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := t.MaxUnits(r) // max units == units
+	if t.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if output := checkFrozen(ctx, db, t.Asset, t.Owner, t.To); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SubAllowance(ctx, db, t.Owner, actor, t.Asset, t.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.SubBalance(ctx, db, t.Owner, t.Asset, t.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	fee, err := withholdFee(ctx, db, t.Asset, t.Value)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, t.To, t.Asset, t.Value-fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return transferResult(unitsUsed, fee)
+}
+
+func (*TransferFrom) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen*2 + consts.IDLen + consts.Uint64Len
+}
+
+func (t *TransferFrom) Marshal(p *codec.Packer) {
+	p.PackPublicKey(t.Owner)
+	p.PackPublicKey(t.To)
+	p.PackID(t.Asset)
+	p.PackUint64(t.Value)
+}
+
+func UnmarshalTransferFrom(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var transfer TransferFrom
+	p.UnpackPublicKey(true, &transfer.Owner)
+	p.UnpackPublicKey(false, &transfer.To) // can transfer to blackhole
+	p.UnpackID(false, &transfer.Asset)     // empty ID is the native asset
+	transfer.Value = p.UnpackUint64(true)
+	return &transfer, p.Err()
+}
+
+func (*TransferFrom) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,86 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var _ chain.Action = (*TransferNFT)(nil)
+
+type TransferNFT struct {
+	// Collection is the [TxID] that created the collection.
+	Collection ids.ID `json:"collection"`
+
+	// ID identifies the NFT within [Collection]. The actor must be the owner
+	// of the NFT.
+	ID uint64 `json:"id"`
+
+	// To is the recipient of the NFT.
+	To crypto.PublicKey `json:"to"`
+}
+
+func (t *TransferNFT) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{storage.PrefixNFTKey(t.Collection, t.ID)}
+}
+
+func (t *TransferNFT) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := t.MaxUnits(r) // max units == units
+	exists, owner, metadata, err := storage.GetNFT(ctx, db, t.Collection, t.ID)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNFTMissing}, nil
+	}
+	if owner != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongOwner}, nil
+	}
+	if err := storage.SetNFT(ctx, db, t.Collection, t.ID, t.To, metadata); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*TransferNFT) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + consts.Uint64Len + crypto.PublicKeyLen
+}
+
+func (t *TransferNFT) Marshal(p *codec.Packer) {
+	p.PackID(t.Collection)
+	p.PackUint64(t.ID)
+	p.PackPublicKey(t.To)
+}
+
+func UnmarshalTransferNFT(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var transfer TransferNFT
+	p.UnpackID(true, &transfer.Collection)
+	transfer.ID = p.UnpackUint64(false)
+	p.UnpackPublicKey(true, &transfer.To)
+	return &transfer, p.Err()
+}
+
+func (*TransferNFT) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
@@ -0,0 +1,117 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*TransferWithMemo)(nil)
+	_ auth.Spender = (*TransferWithMemo)(nil)
+)
+
+// TransferWithMemo is a [Transfer] with a memo. It is a separate action so
+// that the encoding of [Transfer] doesn't change.
+type TransferWithMemo struct {
+	// To is the recipient of the [Value].
+	To crypto.PublicKey `json:"to"`
+
+	// Asset to transfer to [To].
+	Asset ids.ID
+
+	// Amount are transferred to [To].
+	Value uint64 `json:"value"`
+
+	// Memo is a reference (like an invoice ID) that is indexed by the ID of
+	// the action.
+	Memo []byte `json:"memo"`
+}
+
+func (t *TransferWithMemo) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixBalanceKey(actor, t.Asset),
+		storage.PrefixBalanceKey(t.To, t.Asset),
+		storage.PrefixFrozenKey(t.Asset, actor),
+		storage.PrefixFrozenKey(t.Asset, t.To),
+		storage.PrefixAssetKey(t.Asset),
+		storage.PrefixFeesKey(t.Asset),
+	}
+}
+
+func (t *TransferWithMemo) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := t.MaxUnits(r) // max units == units
+	if err := auth.ConsumeAllowance(ctx, db, rauth, t); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if t.Value == 0 {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputValueZero}, nil
+	}
+	if len(t.Memo) > MaxMemoSize {
+		// This should be guarded via [Unmarshal] but we check anyways.
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputMemoTooLarge}, nil
+	}
+	if output := checkFrozen(ctx, db, t.Asset, actor, t.To); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	if err := storage.SubBalance(ctx, db, actor, t.Asset, t.Value); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	fee, err := withholdFee(ctx, db, t.Asset, t.Value)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, t.To, t.Asset, t.Value-fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return transferResult(unitsUsed, fee)
+}
+
+func (t *TransferWithMemo) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return crypto.PublicKeyLen + consts.IDLen + consts.Uint64Len + uint64(len(t.Memo))
+}
+
+func (t *TransferWithMemo) Marshal(p *codec.Packer) {
+	p.PackPublicKey(t.To)
+	p.PackID(t.Asset)
+	p.PackUint64(t.Value)
+	p.PackBytes(t.Memo)
+}
+
+func UnmarshalTransferWithMemo(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var transfer TransferWithMemo
+	p.UnpackPublicKey(false, &transfer.To) // can transfer to blackhole
+	p.UnpackID(false, &transfer.Asset)     // empty ID is the native asset
+	transfer.Value = p.UnpackUint64(true)
+	p.UnpackBytes(MaxMemoSize, true, &transfer.Memo)
+	return &transfer, p.Err()
+}
+
+func (*TransferWithMemo) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (t *TransferWithMemo) Spends() []*auth.Amount {
+	return []*auth.Amount{{Asset: t.Asset, Value: t.Value}}
+}
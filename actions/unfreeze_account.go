@@ -0,0 +1,84 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var _ chain.Action = (*UnfreezeAccount)(nil)
+
+type UnfreezeAccount struct {
+	// Asset is the [TxID] that created the asset. It must have been created
+	// with [Regulated] set.
+	Asset ids.ID `json:"asset"`
+
+	// Account that was previously frozen using [FreezeAccount].
+	Account crypto.PublicKey `json:"account"`
+}
+
+func (u *UnfreezeAccount) StateKeys(chain.Auth, ids.ID) [][]byte {
+	return [][]byte{
+		storage.PrefixAssetKey(u.Asset),
+		storage.PrefixFrozenKey(u.Asset, u.Account),
+	}
+}
+
+func (u *UnfreezeAccount) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := u.MaxUnits(r) // max units == units
+	if output := checkRegulator(ctx, db, u.Asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	frozen, err := storage.GetFrozen(ctx, db, u.Asset, u.Account)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !frozen {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputAccountNotFrozen}, nil
+	}
+	if err := storage.DeleteFrozen(ctx, db, u.Asset, u.Account); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return &chain.Result{Success: true, Units: unitsUsed}, nil
+}
+
+func (*UnfreezeAccount) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen + crypto.PublicKeyLen
+}
+
+func (u *UnfreezeAccount) Marshal(p *codec.Packer) {
+	p.PackID(u.Asset)
+	p.PackPublicKey(u.Account)
+}
+
+func UnmarshalUnfreezeAccount(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var unfreeze UnfreezeAccount
+	p.UnpackID(true, &unfreeze.Asset) // the native asset is never regulated
+	p.UnpackPublicKey(false, &unfreeze.Account)
+	return &unfreeze, p.Err()
+}
+
+func (*UnfreezeAccount) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
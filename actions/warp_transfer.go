@@ -10,13 +10,19 @@ import (
 )
 
 const WarpTransferSize = crypto.PublicKeyLen + consts.IDLen +
-	consts.Uint64Len + 1 + consts.Uint64Len + consts.Uint64Len +
+	consts.IntLen + MaxSymbolSize + 1 + consts.Uint64Len + 1 + consts.Uint64Len + consts.Uint64Len +
 	consts.IDLen + consts.Uint64Len + consts.Uint64Len + consts.IDLen
 
 type WarpTransfer struct {
 	To    crypto.PublicKey `json:"to"`
 	Asset ids.ID           `json:"asset"`
-	Value uint64           `json:"value"`
+
+	// Symbol and Decimals of [Asset] on the chain that sent this message, so
+	// that the imported asset is displayed the same way.
+	Symbol   []byte `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+
+	Value uint64 `json:"value"`
 
 	// Return is set to true when a warp message is sending funds back to the
 	// chain where they were created.
@@ -45,6 +51,8 @@ func (w *WarpTransfer) Marshal() ([]byte, error) {
 	p := codec.NewWriter(WarpTransferSize)
 	p.PackPublicKey(w.To)
 	p.PackID(w.Asset)
+	p.PackBytes(w.Symbol)
+	p.PackByte(w.Decimals)
 	p.PackUint64(w.Value)
 	p.PackBool(w.Return)
 	op := codec.NewOptionalWriter()
@@ -74,6 +82,8 @@ func UnmarshalWarpTransfer(b []byte) (*WarpTransfer, error) {
 	p := codec.NewReader(b, WarpTransferSize)
 	p.UnpackPublicKey(false, &transfer.To)
 	p.UnpackID(false, &transfer.Asset)
+	p.UnpackBytes(MaxSymbolSize, true, &transfer.Symbol)
+	transfer.Decimals = p.UnpackByte()
 	transfer.Value = p.UnpackUint64(true)
 	transfer.Return = p.UnpackBool()
 	op := p.NewOptionalReader()
@@ -0,0 +1,121 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/utils"
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var (
+	_ chain.Action = (*WithdrawStream)(nil)
+	_ auth.Spender = (*WithdrawStream)(nil)
+)
+
+type WithdrawStream struct {
+	// Stream is the [TxID] of the [CreateStream] to withdraw from.
+	Stream ids.ID `json:"stream"`
+
+	// Asset is the asset escrowed in [Stream]. We need to provide this to
+	// populate [StateKeys].
+	Asset ids.ID `json:"asset"`
+}
+
+func (w *WithdrawStream) StateKeys(rauth chain.Auth, _ ids.ID) [][]byte {
+	actor := auth.GetActor(rauth)
+	return [][]byte{
+		storage.PrefixStreamKey(w.Stream),
+		storage.PrefixBalanceKey(actor, w.Asset),
+		storage.PrefixFrozenKey(w.Asset, actor),
+		storage.PrefixAssetKey(w.Asset),
+		storage.PrefixFeesKey(w.Asset),
+	}
+}
+
+func (w *WithdrawStream) Execute(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	t int64,
+	rauth chain.Auth,
+	_ ids.ID,
+	_ bool,
+) (*chain.Result, error) {
+	actor := auth.GetActor(rauth)
+	unitsUsed := w.MaxUnits(r) // max units == units
+	exists, sender, recipient, asset, deposit, rate, start, withdrawn, err := storage.GetStream(ctx, db, w.Stream)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if !exists {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputStreamMissing}, nil
+	}
+	if recipient != actor {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputUnauthorized}, nil
+	}
+	if asset != w.Asset {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputWrongAsset}, nil
+	}
+	if output := checkFrozen(ctx, db, asset, actor); output != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: output}, nil
+	}
+	streamed := StreamedAmount(deposit, rate, start, t)
+	if streamed <= withdrawn {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: OutputNothingStreamed}, nil
+	}
+	if streamed == deposit {
+		// Nothing else can be withdrawn, so we remove the stream.
+		if err := storage.DeleteStream(ctx, db, w.Stream); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	} else {
+		if err := storage.SetStream(
+			ctx, db, w.Stream, sender, recipient, asset,
+			deposit, rate, start, streamed,
+		); err != nil {
+			return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+		}
+	}
+	fee, err := withholdFee(ctx, db, asset, streamed-withdrawn)
+	if err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	if err := storage.AddBalance(ctx, db, actor, asset, streamed-withdrawn-fee); err != nil {
+		return &chain.Result{Success: false, Units: unitsUsed, Output: utils.ErrBytes(err)}, nil
+	}
+	return transferResult(unitsUsed, fee)
+}
+
+func (*WithdrawStream) MaxUnits(chain.Rules) uint64 {
+	// We use size as the price of this transaction but we could just as easily
+	// use any other calculation.
+	return consts.IDLen * 2
+}
+
+func (w *WithdrawStream) Marshal(p *codec.Packer) {
+	p.PackID(w.Stream)
+	p.PackID(w.Asset)
+}
+
+func UnmarshalWithdrawStream(p *codec.Packer, _ *warp.Message) (chain.Action, error) {
+	var withdraw WithdrawStream
+	p.UnpackID(true, &withdraw.Stream)
+	p.UnpackID(false, &withdraw.Asset) // empty ID is the native asset
+	return &withdraw, p.Err()
+}
+
+func (*WithdrawStream) ValidRange(chain.Rules) (int64, int64) {
+	// Returning -1, -1 means that the action is always valid.
+	return -1, -1
+}
+
+func (*WithdrawStream) Spends() []*auth.Amount {
+	// The streamed funds are paid to the actor.
+	return nil
+}
@@ -0,0 +1,12 @@
+package auth
+
+// IDs of the auths in [consts.AuthRegistry]. They must match the order in
+// which auths are registered in [controller/registry].
+const (
+	ED25519ID    uint8 = 0
+	SECP256K1ID  uint8 = 1
+	MultisigID   uint8 = 2
+	SessionKeyID uint8 = 3
+	SponsoredID  uint8 = 4
+	P256ID       uint8 = 5
+)
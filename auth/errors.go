@@ -2,4 +2,22 @@ package auth
 
 import "errors"
 
-var ErrInvalidSignature = errors.New("invalid signature")
+var (
+	ErrInvalidSignature    = errors.New("invalid signature")
+	ErrNotEnoughSignatures = errors.New("not enough signatures")
+	ErrTooManySigners      = errors.New("too many signers")
+	ErrMultisigMissing     = errors.New("multisig is missing")
+	ErrWrongSigners        = errors.New("wrong signers")
+
+	ErrSessionKeyMissing     = errors.New("session key is missing")
+	ErrWrongExpiry           = errors.New("wrong expiry")
+	ErrActionNotAllowed      = errors.New("action not allowed for session key")
+	ErrSpendingLimitExceeded = errors.New("spending limit exceeded")
+	ErrUncappedAction        = errors.New("action can't be capped by session key")
+
+	ErrNotSponsored = errors.New("auth is not sponsored")
+	ErrWrongSponsor = errors.New("wrong sponsor")
+
+	ErrInvalidPublicKey = errors.New("invalid public key")
+	ErrInvalidWebAuthn  = errors.New("invalid webauthn assertion")
+)
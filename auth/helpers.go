@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/crypto"
 )
@@ -9,6 +10,16 @@ func GetActor(auth chain.Auth) crypto.PublicKey {
 	switch a := auth.(type) {
 	case *ED25519:
 		return a.Signer
+	case *SECP256K1:
+		return a.actor()
+	case *Multisig:
+		return a.actor()
+	case *SessionKey:
+		return a.Account
+	case *Sponsored:
+		return a.Actor
+	case *P256:
+		return a.actor()
 	default:
 		return crypto.EmptyPublicKey
 	}
@@ -18,7 +29,34 @@ func GetSigner(auth chain.Auth) crypto.PublicKey {
 	switch a := auth.(type) {
 	case *ED25519:
 		return a.Signer
+	case *SECP256K1:
+		return a.actor()
+	case *Multisig:
+		return a.actor()
+	case *SessionKey:
+		return a.Signer
+	case *Sponsored:
+		return a.Actor
+	case *P256:
+		return a.actor()
 	default:
 		return crypto.EmptyPublicKey
 	}
 }
+
+// authMessage returns the message that is signed to authorize the transaction
+// with digest [msg] with an auth of type [authID] for [accounts].
+//
+// The digest of a transaction doesn't cover its auth, so a signature of [msg]
+// itself would also be a valid [ED25519] signature of the same transaction.
+// Binding the signature to the auth type and the accounts it is used for
+// prevents it from being replayed with a different auth.
+func authMessage(authID uint8, msg []byte, accounts ...crypto.PublicKey) []byte {
+	b := make([]byte, 1+len(accounts)*crypto.PublicKeyLen+len(msg))
+	b[0] = authID
+	for i, account := range accounts {
+		copy(b[1+i*crypto.PublicKeyLen:], account[:])
+	}
+	copy(b[1+len(accounts)*crypto.PublicKeyLen:], msg)
+	return hashing.ComputeHash256(b)
+}
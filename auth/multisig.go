@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/rafael-abuawad/samplevm/storage"
+	"github.com/rafael-abuawad/samplevm/utils"
+)
+
+// MaxMultisigSigners is the maximum number of signers of a multisig account.
+const MaxMultisigSigners = 16
+
+var _ chain.Auth = (*Multisig)(nil)
+
+type MultisigSignature struct {
+	// Signer is the index of the signer in [Multisig.Signers].
+	Signer uint8 `json:"signer"`
+
+	// Signature is over the [authMessage] of the multisig account rather than
+	// over the transaction, so it can't be used as an [ED25519] signature.
+	Signature crypto.Signature `json:"signature"`
+}
+
+type Multisig struct {
+	// Threshold and Signers must match the multisig account registered with
+	// [actions.CreateMultisig]. The account is derived from them.
+	Threshold uint8              `json:"threshold"`
+	Signers   []crypto.PublicKey `json:"signers"`
+
+	// Signatures must be ordered by [MultisigSignature.Signer] and contain at
+	// least [Threshold] entries.
+	Signatures []*MultisigSignature `json:"signatures"`
+}
+
+func (m *Multisig) actor() crypto.PublicKey {
+	return utils.MultisigActor(m.Threshold, m.Signers)
+}
+
+func (m *Multisig) MaxUnits(
+	chain.Rules,
+) uint64 {
+	// make signatures more expensive
+	return 1 + uint64(len(m.Signers))*crypto.PublicKeyLen + uint64(len(m.Signatures))*(1+crypto.SignatureLen*5)
+}
+
+func (*Multisig) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (m *Multisig) StateKeys() [][]byte {
+	actor := m.actor()
+	return [][]byte{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		storage.PrefixBalanceKey(actor, ids.Empty),
+		storage.PrefixMultisigKey(actor),
+	}
+}
+
+func (m *Multisig) AsyncVerify(msg []byte) error {
+	if m.Threshold == 0 || len(m.Signatures) < int(m.Threshold) {
+		return ErrNotEnoughSignatures
+	}
+	msg = authMessage(MultisigID, msg, m.actor())
+	for i, sig := range m.Signatures {
+		// Requiring increasing indices ensures that no signer is counted twice.
+		if i > 0 && sig.Signer <= m.Signatures[i-1].Signer {
+			return ErrInvalidSignature
+		}
+		if int(sig.Signer) >= len(m.Signers) {
+			return ErrInvalidSignature
+		}
+		if !crypto.Verify(msg, m.Signers[sig.Signer], sig.Signature) {
+			return ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+func (m *Multisig) Verify(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	_ chain.Action,
+) (uint64, error) {
+	exists, threshold, signers, err := storage.GetMultisig(ctx, db, m.actor())
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, ErrMultisigMissing
+	}
+	// The account is derived from its threshold and signers, so this only
+	// fails if there is a hash collision.
+	if threshold != m.Threshold || len(signers) != len(m.Signers) {
+		return 0, ErrWrongSigners
+	}
+	for i, signer := range signers {
+		if signer != m.Signers[i] {
+			return 0, ErrWrongSigners
+		}
+	}
+	return m.MaxUnits(r), nil
+}
+
+func (m *Multisig) Payer() []byte {
+	actor := m.actor()
+	return actor[:]
+}
+
+func (m *Multisig) Marshal(p *codec.Packer) {
+	p.PackByte(m.Threshold)
+	p.PackInt(len(m.Signers))
+	for _, signer := range m.Signers {
+		p.PackPublicKey(signer)
+	}
+	p.PackInt(len(m.Signatures))
+	for _, sig := range m.Signatures {
+		p.PackByte(sig.Signer)
+		p.PackSignature(sig.Signature)
+	}
+}
+
+func UnmarshalMultisig(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var m Multisig
+	m.Threshold = p.UnpackByte()
+	signers := p.UnpackInt(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if signers > MaxMultisigSigners {
+		return nil, ErrTooManySigners
+	}
+	m.Signers = make([]crypto.PublicKey, signers)
+	for i := 0; i < signers; i++ {
+		p.UnpackPublicKey(true, &m.Signers[i])
+	}
+	signatures := p.UnpackInt(true)
+	if err := p.Err(); err != nil {
+		return nil, err
+	}
+	if signatures > signers {
+		return nil, ErrTooManySigners
+	}
+	m.Signatures = make([]*MultisigSignature, signatures)
+	for i := 0; i < signatures; i++ {
+		var sig MultisigSignature
+		sig.Signer = p.UnpackByte()
+		p.UnpackSignature(&sig.Signature)
+		m.Signatures[i] = &sig
+	}
+	return &m, p.Err()
+}
+
+func (m *Multisig) CanDeduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, db, m.actor(), ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (m *Multisig) Deduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, db, m.actor(), ids.Empty, amount)
+}
+
+func (m *Multisig) Refund(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return storage.AddBalance(ctx, db, m.actor(), ids.Empty, amount)
+}
+
+var _ chain.AuthFactory = (*MultisigFactory)(nil)
+
+// NewMultisigFactory returns a factory that signs for the multisig account of
+// [signers] and [threshold] with each of [privs].
+func NewMultisigFactory(
+	threshold uint8,
+	signers []crypto.PublicKey,
+	privs ...crypto.PrivateKey,
+) *MultisigFactory {
+	return &MultisigFactory{threshold, signers, privs}
+}
+
+type MultisigFactory struct {
+	threshold uint8
+	signers   []crypto.PublicKey
+	privs     []crypto.PrivateKey
+}
+
+func (m *MultisigFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	msg = authMessage(MultisigID, msg, utils.MultisigActor(m.threshold, m.signers))
+	sigs := []*MultisigSignature{}
+	for i, signer := range m.signers {
+		for _, priv := range m.privs {
+			if priv.PublicKey() != signer {
+				continue
+			}
+			sigs = append(sigs, &MultisigSignature{uint8(i), crypto.Sign(msg, priv)})
+			break
+		}
+	}
+	if len(sigs) < int(m.threshold) {
+		return nil, ErrNotEnoughSignatures
+	}
+	return &Multisig{m.threshold, m.signers, sigs}, nil
+}
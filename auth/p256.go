@@ -0,0 +1,250 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/rafael-abuawad/samplevm/storage"
+	"github.com/rafael-abuawad/samplevm/utils"
+)
+
+const (
+	// P256PublicKeyLen is the length of a compressed P-256 public key.
+	P256PublicKeyLen = 33
+
+	// P256SignatureLen is the length of a P-256 signature encoded as r||s.
+	P256SignatureLen = 64
+
+	// MaxWebAuthnDataSize is the maximum size of the authenticator data and
+	// of the client data of a WebAuthn assertion.
+	MaxWebAuthnDataSize = 1024
+
+	// webAuthnType is the type of the client data of an assertion.
+	webAuthnType = "webauthn.get"
+
+	// Authenticator data starts with the hash of the relying party ID (32
+	// bytes), followed by flags (1 byte) and a signature counter (4 bytes).
+	minAuthenticatorDataLen = 37
+	flagsIndex              = 32
+	userPresentFlag         = 0x01
+)
+
+var (
+	_ chain.Auth = (*P256)(nil)
+
+	p256HalfOrder = new(big.Int).Rsh(elliptic.P256().Params().N, 1)
+)
+
+type P256 struct {
+	// Signer is the compressed public key of the signer.
+	Signer []byte `json:"signer"`
+
+	// AuthenticatorData and ClientDataJSON are set when the transaction is
+	// signed with WebAuthn (as passkeys do). In that case, the challenge of
+	// [ClientDataJSON] must be [WebAuthnChallenge] of the transaction and
+	// [Signature] is over the assertion rather than over the transaction.
+	//
+	// Both are empty when the transaction is signed directly.
+	AuthenticatorData []byte `json:"authenticatorData"`
+	ClientDataJSON    []byte `json:"clientDataJSON"`
+
+	// Signature is encoded as r||s with s in the lower half of the curve
+	// order. Authenticators return DER-encoded signatures with any s, so
+	// wallets must convert and normalize them (s = n-s) before issuance.
+	// Otherwise, anyone could change the ID of a transaction by negating s.
+	Signature []byte `json:"signature"`
+}
+
+func (d *P256) actor() crypto.PublicKey {
+	return utils.P256Actor(d.Signer)
+}
+
+func (d *P256) webAuthn() bool {
+	return len(d.AuthenticatorData) > 0 || len(d.ClientDataJSON) > 0
+}
+
+func (d *P256) MaxUnits(
+	chain.Rules,
+) uint64 {
+	// P-256 signatures are more expensive to verify than ED25519 signatures
+	return P256PublicKeyLen + P256SignatureLen*10 +
+		uint64(len(d.AuthenticatorData)+len(d.ClientDataJSON))
+}
+
+func (*P256) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (d *P256) StateKeys() [][]byte {
+	return [][]byte{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		storage.PrefixBalanceKey(d.actor(), ids.Empty),
+	}
+}
+
+func (d *P256) AsyncVerify(msg []byte) error {
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), d.Signer)
+	if x == nil {
+		return ErrInvalidPublicKey
+	}
+	pk := &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}
+	r := new(big.Int).SetBytes(d.Signature[:P256SignatureLen/2])
+	s := new(big.Int).SetBytes(d.Signature[P256SignatureLen/2:])
+	if s.Cmp(p256HalfOrder) > 0 {
+		return ErrInvalidSignature
+	}
+	digest, err := d.digest(msg)
+	if err != nil {
+		return err
+	}
+	if !ecdsa.Verify(pk, digest, r, s) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// digest returns the hash signed by [Signer] to authorize [msg].
+func (d *P256) digest(msg []byte) ([]byte, error) {
+	if !d.webAuthn() {
+		digest := sha256.Sum256(msg)
+		return digest[:], nil
+	}
+	if len(d.AuthenticatorData) < minAuthenticatorDataLen {
+		return nil, ErrInvalidWebAuthn
+	}
+	if d.AuthenticatorData[flagsIndex]&userPresentFlag == 0 {
+		return nil, ErrInvalidWebAuthn
+	}
+	var clientData struct {
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+	}
+	if err := json.Unmarshal(d.ClientDataJSON, &clientData); err != nil {
+		return nil, ErrInvalidWebAuthn
+	}
+	if clientData.Type != webAuthnType || clientData.Challenge != WebAuthnChallenge(msg) {
+		return nil, ErrInvalidWebAuthn
+	}
+	clientDataHash := sha256.Sum256(d.ClientDataJSON)
+	digest := sha256.Sum256(append(bytes.Clone(d.AuthenticatorData), clientDataHash[:]...))
+	return digest[:], nil
+}
+
+func (d *P256) Verify(
+	_ context.Context,
+	r chain.Rules,
+	_ chain.Database,
+	_ chain.Action,
+) (uint64, error) {
+	// We don't do anything during verify (there is no additional state to check
+	// to authorize the signer other than verifying the signature)
+	return d.MaxUnits(r), nil
+}
+
+func (d *P256) Payer() []byte {
+	actor := d.actor()
+	return actor[:]
+}
+
+func (d *P256) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(d.Signer)
+	p.PackBytes(d.AuthenticatorData)
+	p.PackBytes(d.ClientDataJSON)
+	p.PackFixedBytes(d.Signature)
+}
+
+func UnmarshalP256(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	d := P256{
+		Signer:    make([]byte, P256PublicKeyLen),
+		Signature: make([]byte, P256SignatureLen),
+	}
+	p.UnpackFixedBytes(P256PublicKeyLen, &d.Signer)
+	p.UnpackBytes(MaxWebAuthnDataSize, false, &d.AuthenticatorData)
+	p.UnpackBytes(MaxWebAuthnDataSize, false, &d.ClientDataJSON)
+	p.UnpackFixedBytes(P256SignatureLen, &d.Signature)
+	return &d, p.Err()
+}
+
+func (d *P256) CanDeduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, db, d.actor(), ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (d *P256) Deduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, db, d.actor(), ids.Empty, amount)
+}
+
+func (d *P256) Refund(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return storage.AddBalance(ctx, db, d.actor(), ids.Empty, amount)
+}
+
+// WebAuthnChallenge returns the challenge a WebAuthn assertion must sign to
+// authorize the transaction with digest [msg].
+func WebAuthnChallenge(msg []byte) string {
+	challenge := sha256.Sum256(msg)
+	return base64.RawURLEncoding.EncodeToString(challenge[:])
+}
+
+// P256Signature encodes [r] and [s] as r||s, replacing [s] with n-s if it is
+// in the upper half of the curve order.
+func P256Signature(r *big.Int, s *big.Int) []byte {
+	if s.Cmp(p256HalfOrder) > 0 {
+		s = new(big.Int).Sub(elliptic.P256().Params().N, s)
+	}
+	sig := make([]byte, P256SignatureLen)
+	r.FillBytes(sig[:P256SignatureLen/2])
+	s.FillBytes(sig[P256SignatureLen/2:])
+	return sig
+}
+
+var _ chain.AuthFactory = (*P256Factory)(nil)
+
+func NewP256Factory(priv *ecdsa.PrivateKey) *P256Factory {
+	return &P256Factory{priv}
+}
+
+type P256Factory struct {
+	priv *ecdsa.PrivateKey
+}
+
+func (d *P256Factory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	digest := sha256.Sum256(msg)
+	r, s, err := ecdsa.Sign(rand.Reader, d.priv, digest[:])
+	if err != nil {
+		return nil, err
+	}
+	return &P256{
+		Signer:    elliptic.MarshalCompressed(elliptic.P256(), d.priv.X, d.priv.Y),
+		Signature: P256Signature(r, s),
+	}, nil
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/rafael-abuawad/samplevm/storage"
+	"github.com/rafael-abuawad/samplevm/utils"
+)
+
+var _ chain.Auth = (*SECP256K1)(nil)
+
+type SECP256K1 struct {
+	// Signer is the compressed public key of the signer.
+	Signer []byte `json:"signer"`
+
+	// Signature is a recoverable signature of the transaction. The public key
+	// recovered from it must match [Signer].
+	Signature []byte `json:"signature"`
+}
+
+func (d *SECP256K1) actor() crypto.PublicKey {
+	return utils.SECP256K1Actor(d.Signer)
+}
+
+func (*SECP256K1) MaxUnits(
+	chain.Rules,
+) uint64 {
+	return secp256k1.PublicKeyLen + secp256k1.SignatureLen*5 // make signatures more expensive
+}
+
+func (*SECP256K1) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (d *SECP256K1) StateKeys() [][]byte {
+	return [][]byte{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		storage.PrefixBalanceKey(d.actor(), ids.Empty),
+	}
+}
+
+func (d *SECP256K1) AsyncVerify(msg []byte) error {
+	factory := secp256k1.Factory{}
+	pk, err := factory.RecoverPublicKey(msg, d.Signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !bytes.Equal(pk.Bytes(), d.Signer) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (d *SECP256K1) Verify(
+	_ context.Context,
+	r chain.Rules,
+	_ chain.Database,
+	_ chain.Action,
+) (uint64, error) {
+	// We don't do anything during verify (there is no additional state to check
+	// to authorize the signer other than verifying the signature)
+	return d.MaxUnits(r), nil
+}
+
+func (d *SECP256K1) Payer() []byte {
+	actor := d.actor()
+	return actor[:]
+}
+
+func (d *SECP256K1) Marshal(p *codec.Packer) {
+	p.PackFixedBytes(d.Signer)
+	p.PackFixedBytes(d.Signature)
+}
+
+func UnmarshalSECP256K1(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	d := SECP256K1{
+		Signer:    make([]byte, secp256k1.PublicKeyLen),
+		Signature: make([]byte, secp256k1.SignatureLen),
+	}
+	p.UnpackFixedBytes(secp256k1.PublicKeyLen, &d.Signer)
+	p.UnpackFixedBytes(secp256k1.SignatureLen, &d.Signature)
+	return &d, p.Err()
+}
+
+func (d *SECP256K1) CanDeduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, db, d.actor(), ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (d *SECP256K1) Deduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, db, d.actor(), ids.Empty, amount)
+}
+
+func (d *SECP256K1) Refund(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return storage.AddBalance(ctx, db, d.actor(), ids.Empty, amount)
+}
+
+var _ chain.AuthFactory = (*SECP256K1Factory)(nil)
+
+func NewSECP256K1Factory(priv *secp256k1.PrivateKey) *SECP256K1Factory {
+	return &SECP256K1Factory{priv}
+}
+
+type SECP256K1Factory struct {
+	priv *secp256k1.PrivateKey
+}
+
+func (d *SECP256K1Factory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	sig, err := d.priv.Sign(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &SECP256K1{d.priv.PublicKey().Bytes(), sig}, nil
+}
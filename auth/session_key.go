@@ -0,0 +1,382 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/consts"
+	"github.com/ava-labs/hypersdk/crypto"
+	tconsts "github.com/rafael-abuawad/samplevm/consts"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+const (
+	// MaxSessionKeyActions is the maximum number of action types a session
+	// key can be allowed to use.
+	MaxSessionKeyActions = 32
+
+	// MaxSessionKeyLimits is the maximum number of assets a session key can
+	// have a spending limit for.
+	MaxSessionKeyLimits = 16
+)
+
+// Amount is an amount of [Asset].
+type Amount struct {
+	Asset ids.ID `json:"asset"`
+	Value uint64 `json:"value"`
+}
+
+// Spender is implemented by actions that can be authorized by a [SessionKey].
+// [Spends] returns the assets the action moves out of the account of the
+// actor, which count towards the limits of the session key. Actions that move
+// value the limits can't cap (like NFTs, allowances granted by other accounts
+// or the ownership of an asset) don't implement it, so they are rejected.
+//
+// Auths can't modify state based on the action they authorize, so a [Spender]
+// must call [ConsumeAllowance] when it is executed.
+type Spender interface {
+	Spends() []*Amount
+}
+
+// Composite is implemented by actions that execute other actions. A
+// [SessionKey] must be allowed to use each of them.
+type Composite interface {
+	Inner() []chain.Action
+}
+
+var _ chain.Auth = (*SessionKey)(nil)
+
+type SessionKey struct {
+	// Account is the account that registered [Signer] with
+	// [actions.RegisterSessionKey]. It is the actor of the transaction and
+	// pays its fees.
+	Account crypto.PublicKey `json:"account"`
+
+	// Signer is the session key that signed the transaction.
+	Signer crypto.PublicKey `json:"signer"`
+
+	// Expiry must match the expiry of the registered session key. It is
+	// included so that the transaction is rejected without reading state once
+	// the session key has expired.
+	Expiry int64 `json:"expiry"`
+
+	// Signature is over the [authMessage] of [Account] rather than over the
+	// transaction, so transactions [Signer] signs for its own account can't be
+	// replayed on behalf of [Account].
+	Signature crypto.Signature `json:"signature"`
+}
+
+func (*SessionKey) MaxUnits(
+	chain.Rules,
+) uint64 {
+	return crypto.PublicKeyLen*2 + consts.Uint64Len + crypto.SignatureLen*5 // make signatures more expensive
+}
+
+func (s *SessionKey) ValidRange(chain.Rules) (int64, int64) {
+	return -1, s.Expiry
+}
+
+func (s *SessionKey) StateKeys() [][]byte {
+	return [][]byte{
+		// We always pay fees with the native asset (which is [ids.Empty])
+		storage.PrefixBalanceKey(s.Account, ids.Empty),
+		storage.PrefixSessionKeyKey(s.Account, s.Signer),
+	}
+}
+
+func (s *SessionKey) AsyncVerify(msg []byte) error {
+	if !crypto.Verify(authMessage(SessionKeyID, msg, s.Account), s.Signer, s.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *SessionKey) Verify(
+	ctx context.Context,
+	r chain.Rules,
+	db chain.Database,
+	action chain.Action,
+) (uint64, error) {
+	// [Verify] only checks that the action is within the remaining allowance.
+	// The allowance is consumed when the action is executed (see
+	// [ConsumeAllowance]).
+	exists, expiry, allowed, assets, remaining, err := storage.GetSessionKey(ctx, db, s.Account, s.Signer)
+	if err != nil {
+		return 0, err
+	}
+	if !exists {
+		return 0, ErrSessionKeyMissing
+	}
+	if expiry != s.Expiry {
+		return 0, ErrWrongExpiry
+	}
+	if !actionAllowed(action, allowed) {
+		return 0, ErrActionNotAllowed
+	}
+	spends, err := actionSpends(action)
+	if err != nil {
+		return 0, err
+	}
+	for _, spend := range spends {
+		if spend.Value > remainingAllowance(spend.Asset, assets, remaining) {
+			return 0, ErrSpendingLimitExceeded
+		}
+	}
+	return s.MaxUnits(r), nil
+}
+
+func (s *SessionKey) Payer() []byte {
+	return s.Account[:]
+}
+
+func (s *SessionKey) Marshal(p *codec.Packer) {
+	p.PackPublicKey(s.Account)
+	p.PackPublicKey(s.Signer)
+	p.PackInt64(s.Expiry)
+	p.PackSignature(s.Signature)
+}
+
+func UnmarshalSessionKey(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var s SessionKey
+	p.UnpackPublicKey(true, &s.Account)
+	p.UnpackPublicKey(true, &s.Signer)
+	s.Expiry = p.UnpackInt64(true)
+	p.UnpackSignature(&s.Signature)
+	return &s, p.Err()
+}
+
+// Fees are paid with the native asset of [Account], so they count towards the
+// native spending limit of the session key. Otherwise, a leaked session key
+// could spend the whole native balance of [Account] on fees.
+func (s *SessionKey) CanDeduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, db, s.Account, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	exists, _, _, assets, remaining, err := storage.GetSessionKey(ctx, db, s.Account, s.Signer)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrSessionKeyMissing
+	}
+	if amount > remainingAllowance(ids.Empty, assets, remaining) {
+		return ErrSpendingLimitExceeded
+	}
+	return nil
+}
+
+func (s *SessionKey) Deduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	if err := storage.SubBalance(ctx, db, s.Account, ids.Empty, amount); err != nil {
+		return err
+	}
+	exists, expiry, allowed, assets, remaining, err := storage.GetSessionKey(ctx, db, s.Account, s.Signer)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrSessionKeyMissing
+	}
+	i := allowanceIndex(ids.Empty, assets)
+	if i < 0 || amount > remaining[i] {
+		return ErrSpendingLimitExceeded
+	}
+	remaining[i] -= amount
+	return storage.SetSessionKey(ctx, db, s.Account, s.Signer, expiry, allowed, assets, remaining)
+}
+
+func (s *SessionKey) Refund(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	if err := storage.AddBalance(ctx, db, s.Account, ids.Empty, amount); err != nil {
+		return err
+	}
+	exists, expiry, allowed, assets, remaining, err := storage.GetSessionKey(ctx, db, s.Account, s.Signer)
+	if err != nil {
+		return err
+	}
+	i := allowanceIndex(ids.Empty, assets)
+	if !exists || i < 0 {
+		return nil
+	}
+	newRemaining, err := smath.Add64(remaining[i], amount)
+	if err != nil {
+		return err
+	}
+	remaining[i] = newRemaining
+	return storage.SetSessionKey(ctx, db, s.Account, s.Signer, expiry, allowed, assets, remaining)
+}
+
+// ConsumeAllowance deducts the amounts spent by [spender] from the remaining
+// allowance of the session key that authorized it. It does nothing if
+// [rauth] is not a [SessionKey].
+//
+// It is called during execution, so the allowance is restored if the action
+// fails.
+func ConsumeAllowance(
+	ctx context.Context,
+	db chain.Database,
+	rauth chain.Auth,
+	spender Spender,
+) error {
+	s, ok := rauth.(*SessionKey)
+	if !ok {
+		return nil
+	}
+	spends := spender.Spends()
+	if len(spends) == 0 {
+		return nil
+	}
+	exists, expiry, allowed, assets, remaining, err := storage.GetSessionKey(ctx, db, s.Account, s.Signer)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrSessionKeyMissing
+	}
+	for _, spend := range spends {
+		if spend.Value == 0 {
+			continue
+		}
+		i := allowanceIndex(spend.Asset, assets)
+		if i < 0 || spend.Value > remaining[i] {
+			return ErrSpendingLimitExceeded
+		}
+		remaining[i] -= spend.Value
+	}
+	return storage.SetSessionKey(ctx, db, s.Account, s.Signer, expiry, allowed, assets, remaining)
+}
+
+// actionAllowed returns true if [action], and every action it executes, has
+// a type in [allowed].
+func actionAllowed(action chain.Action, allowed []uint8) bool {
+	typeID, _, _, ok := tconsts.ActionRegistry.LookupType(action)
+	if !ok {
+		return false
+	}
+	found := false
+	for _, a := range allowed {
+		if a == typeID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+	if c, ok := action.(Composite); ok {
+		for _, inner := range c.Inner() {
+			if !actionAllowed(inner, allowed) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// actionSpends returns the total amount of each asset spent by [action] and
+// every action it executes. It returns [ErrUncappedAction] if any of them
+// doesn't implement [Spender].
+func actionSpends(action chain.Action) ([]*Amount, error) {
+	totals := []*Amount{}
+	var add func(chain.Action) error
+	add = func(action chain.Action) error {
+		c, composite := action.(Composite)
+		if composite {
+			for _, inner := range c.Inner() {
+				if err := add(inner); err != nil {
+					return err
+				}
+			}
+		}
+		s, ok := action.(Spender)
+		if !ok {
+			if composite {
+				return nil
+			}
+			return ErrUncappedAction
+		}
+		for _, spend := range s.Spends() {
+			var total *Amount
+			for _, t := range totals {
+				if t.Asset == spend.Asset {
+					total = t
+					break
+				}
+			}
+			if total == nil {
+				total = &Amount{Asset: spend.Asset}
+				totals = append(totals, total)
+			}
+			value, err := smath.Add64(total.Value, spend.Value)
+			if err != nil {
+				return err
+			}
+			total.Value = value
+		}
+		return nil
+	}
+	if err := add(action); err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// remainingAllowance returns the amount of [asset] that can still be spent.
+// Assets without a spending limit can't be spent.
+func remainingAllowance(asset ids.ID, assets []ids.ID, remaining []uint64) uint64 {
+	if i := allowanceIndex(asset, assets); i >= 0 {
+		return remaining[i]
+	}
+	return 0
+}
+
+// allowanceIndex returns the index of the spending limit of [asset] in
+// [assets], or -1 if there is none.
+func allowanceIndex(asset ids.ID, assets []ids.ID) int {
+	for i, a := range assets {
+		if a == asset {
+			return i
+		}
+	}
+	return -1
+}
+
+var _ chain.AuthFactory = (*SessionKeyFactory)(nil)
+
+func NewSessionKeyFactory(account crypto.PublicKey, expiry int64, priv crypto.PrivateKey) *SessionKeyFactory {
+	return &SessionKeyFactory{account, expiry, priv}
+}
+
+type SessionKeyFactory struct {
+	account crypto.PublicKey
+	expiry  int64
+	priv    crypto.PrivateKey
+}
+
+func (s *SessionKeyFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	sig := crypto.Sign(authMessage(SessionKeyID, msg, s.account), s.priv)
+	return &SessionKey{
+		Account:   s.account,
+		Signer:    s.priv.PublicKey(),
+		Expiry:    s.expiry,
+		Signature: sig,
+	}, nil
+}
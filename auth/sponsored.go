@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/codec"
+	"github.com/ava-labs/hypersdk/crypto"
+	"github.com/rafael-abuawad/samplevm/storage"
+)
+
+var _ chain.Auth = (*Sponsored)(nil)
+
+type Sponsored struct {
+	// Actor is the account that issues the action.
+	Actor          crypto.PublicKey `json:"actor"`
+	ActorSignature crypto.Signature `json:"actorSignature"`
+
+	// Sponsor is the account that pays the fees of the transaction. It signs
+	// the same message as [Actor], after [Actor] has signed it.
+	//
+	// Both sign the [authMessage] of [Actor] and [Sponsor] rather than the
+	// transaction, so neither signature can be used as an [ED25519] signature
+	// or with the roles swapped.
+	Sponsor          crypto.PublicKey `json:"sponsor"`
+	SponsorSignature crypto.Signature `json:"sponsorSignature"`
+}
+
+func (*Sponsored) MaxUnits(
+	chain.Rules,
+) uint64 {
+	return (crypto.PublicKeyLen + crypto.SignatureLen*5) * 2 // make signatures more expensive
+}
+
+func (*Sponsored) ValidRange(chain.Rules) (int64, int64) {
+	return -1, -1
+}
+
+func (s *Sponsored) StateKeys() [][]byte {
+	return [][]byte{
+		storage.PrefixBalanceKey(s.Actor, ids.Empty),
+		// Fees are paid by [Sponsor] with the native asset (which is [ids.Empty])
+		storage.PrefixBalanceKey(s.Sponsor, ids.Empty),
+	}
+}
+
+func (s *Sponsored) AsyncVerify(msg []byte) error {
+	msg = authMessage(SponsoredID, msg, s.Actor, s.Sponsor)
+	if !crypto.Verify(msg, s.Actor, s.ActorSignature) {
+		return ErrInvalidSignature
+	}
+	if !crypto.Verify(msg, s.Sponsor, s.SponsorSignature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func (s *Sponsored) Verify(
+	_ context.Context,
+	r chain.Rules,
+	_ chain.Database,
+	_ chain.Action,
+) (uint64, error) {
+	// We don't do anything during verify (there is no additional state to check
+	// to authorize the signers other than verifying the signatures)
+	return s.MaxUnits(r), nil
+}
+
+func (s *Sponsored) Payer() []byte {
+	return s.Sponsor[:]
+}
+
+func (s *Sponsored) Marshal(p *codec.Packer) {
+	p.PackPublicKey(s.Actor)
+	p.PackSignature(s.ActorSignature)
+	p.PackPublicKey(s.Sponsor)
+	p.PackSignature(s.SponsorSignature)
+}
+
+func UnmarshalSponsored(p *codec.Packer, _ *warp.Message) (chain.Auth, error) {
+	var s Sponsored
+	p.UnpackPublicKey(true, &s.Actor)
+	p.UnpackSignature(&s.ActorSignature)
+	p.UnpackPublicKey(true, &s.Sponsor)
+	// [SponsorSignature] is empty until [Sponsor] countersigns the transaction
+	// (which is rejected by [AsyncVerify] before then).
+	sig := make([]byte, crypto.SignatureLen)
+	p.UnpackFixedBytes(crypto.SignatureLen, &sig)
+	copy(s.SponsorSignature[:], sig)
+	return &s, p.Err()
+}
+
+func (s *Sponsored) CanDeduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	bal, err := storage.GetBalance(ctx, db, s.Sponsor, ids.Empty)
+	if err != nil {
+		return err
+	}
+	if bal < amount {
+		return storage.ErrInvalidBalance
+	}
+	return nil
+}
+
+func (s *Sponsored) Deduct(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return storage.SubBalance(ctx, db, s.Sponsor, ids.Empty, amount)
+}
+
+func (s *Sponsored) Refund(
+	ctx context.Context,
+	db chain.Database,
+	amount uint64,
+) error {
+	return storage.AddBalance(ctx, db, s.Sponsor, ids.Empty, amount)
+}
+
+var _ chain.AuthFactory = (*SponsoredFactory)(nil)
+
+// NewSponsoredFactory returns a factory that signs transactions as the actor
+// of a [Sponsored] auth. The resulting transaction can't be issued until
+// [sponsor] countersigns it with a [SponsorFactory].
+func NewSponsoredFactory(priv crypto.PrivateKey, sponsor crypto.PublicKey) *SponsoredFactory {
+	return &SponsoredFactory{priv, sponsor}
+}
+
+type SponsoredFactory struct {
+	priv    crypto.PrivateKey
+	sponsor crypto.PublicKey
+}
+
+func (s *SponsoredFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	actor := s.priv.PublicKey()
+	return &Sponsored{
+		Actor:          actor,
+		ActorSignature: crypto.Sign(authMessage(SponsoredID, msg, actor, s.sponsor), s.priv),
+		Sponsor:        s.sponsor,
+	}, nil
+}
+
+var _ chain.AuthFactory = (*SponsorFactory)(nil)
+
+// NewSponsorFactory returns a factory that countersigns [auth], which must
+// already be signed by its actor, as its sponsor.
+func NewSponsorFactory(priv crypto.PrivateKey, auth *Sponsored) *SponsorFactory {
+	return &SponsorFactory{priv, auth}
+}
+
+type SponsorFactory struct {
+	priv crypto.PrivateKey
+	auth *Sponsored
+}
+
+func (s *SponsorFactory) Sign(msg []byte, _ chain.Action) (chain.Auth, error) {
+	if s.priv.PublicKey() != s.auth.Sponsor {
+		return nil, ErrWrongSponsor
+	}
+	msg = authMessage(SponsoredID, msg, s.auth.Actor, s.auth.Sponsor)
+	if !crypto.Verify(msg, s.auth.Actor, s.auth.ActorSignature) {
+		return nil, ErrInvalidSignature
+	}
+	return &Sponsored{
+		Actor:            s.auth.Actor,
+		ActorSignature:   s.auth.ActorSignature,
+		Sponsor:          s.auth.Sponsor,
+		SponsorSignature: crypto.Sign(msg, s.priv),
+	}, nil
+}
@@ -3,24 +3,33 @@ package client
 import (
 	"context"
 	"strings"
+	"sync"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/client"
 
+	"github.com/rafael-abuawad/samplevm/auth"
 	"github.com/rafael-abuawad/samplevm/consts"
 	"github.com/rafael-abuawad/samplevm/controller"
 	"github.com/rafael-abuawad/samplevm/genesis"
+	"github.com/rafael-abuawad/samplevm/orderbook"
 )
 
 type Client struct {
 	*client.Client // embed standard functionality
 
 	g *genesis.Genesis
+
+	decimalsL sync.Mutex
+	decimals  map[ids.ID]uint8
 }
 
 // New creates a new client object.
 func New(uri string) *Client {
-	return &Client{Client: client.New(consts.Name, uri)}
+	return &Client{
+		Client:   client.New(consts.Name, uri),
+		decimals: map[ids.ID]uint8{},
+	}
 }
 
 func (cli *Client) Genesis(ctx context.Context) (*genesis.Genesis, error) {
@@ -42,7 +51,7 @@ func (cli *Client) Genesis(ctx context.Context) (*genesis.Genesis, error) {
 	return resp.Genesis, nil
 }
 
-func (cli *Client) Tx(ctx context.Context, id ids.ID) (bool, bool, int64, error) {
+func (cli *Client) Tx(ctx context.Context, id ids.ID) (bool, bool, int64, []byte, error) {
 	resp := new(controller.TxReply)
 	err := cli.Requester.SendRequest(
 		ctx,
@@ -54,17 +63,28 @@ func (cli *Client) Tx(ctx context.Context, id ids.ID) (bool, bool, int64, error)
 	// We use string parsing here because the JSON-RPC library we use may not
 	// allows us to perform errors.Is.
 	case err != nil && strings.Contains(err.Error(), controller.ErrTxNotFound.Error()):
-		return false, false, -1, nil
+		return false, false, -1, nil, nil
 	case err != nil:
-		return false, false, -1, err
+		return false, false, -1, nil, err
 	}
-	return true, resp.Success, resp.Timestamp, nil
+	return true, resp.Success, resp.Timestamp, resp.Memo, nil
+}
+
+func (cli *Client) Memo(ctx context.Context, actionID ids.ID) ([]byte, error) {
+	resp := new(controller.MemoReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"memo",
+		&controller.MemoArgs{ActionID: actionID},
+		resp,
+	)
+	return resp.Memo, err
 }
 
 func (cli *Client) Asset(
 	ctx context.Context,
 	asset ids.ID,
-) (bool, []byte, uint64, string, bool, error) {
+) (bool, *controller.AssetReply, error) {
 	resp := new(controller.AssetReply)
 	err := cli.Requester.SendRequest(
 		ctx,
@@ -78,11 +98,38 @@ func (cli *Client) Asset(
 	// We use string parsing here because the JSON-RPC library we use may not
 	// allows us to perform errors.Is.
 	case err != nil && strings.Contains(err.Error(), controller.ErrAssetNotFound.Error()):
-		return false, nil, 0, "", false, nil
+		return false, nil, nil
 	case err != nil:
-		return false, nil, 0, "", false, err
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+// Decimals returns the number of decimal places used to display [asset].
+// Decimals can't be changed once an asset is created, so they are cached
+// after the first lookup.
+func (cli *Client) Decimals(ctx context.Context, asset ids.ID) (uint8, error) {
+	if asset == ids.Empty {
+		return consts.Decimals, nil
+	}
+	cli.decimalsL.Lock()
+	decimals, ok := cli.decimals[asset]
+	cli.decimalsL.Unlock()
+	if ok {
+		return decimals, nil
+	}
+
+	exists, resp, err := cli.Asset(ctx, asset)
+	if err != nil {
+		return 0, err
 	}
-	return true, resp.Metadata, resp.Supply, resp.Owner, resp.Warp, nil
+	if !exists {
+		return 0, controller.ErrAssetNotFound
+	}
+	cli.decimalsL.Lock()
+	cli.decimals[asset] = resp.Decimals
+	cli.decimalsL.Unlock()
+	return resp.Decimals, nil
 }
 
 func (cli *Client) Balance(ctx context.Context, addr string, asset ids.ID) (uint64, error) {
@@ -98,3 +145,348 @@ func (cli *Client) Balance(ctx context.Context, addr string, asset ids.ID) (uint
 	)
 	return resp.Amount, err
 }
+
+func (cli *Client) Loan(
+	ctx context.Context,
+	asset ids.ID,
+	destination ids.ID,
+) (uint64, error) {
+	resp := new(controller.LoanReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"loan",
+		&controller.LoanArgs{
+			Asset:       asset,
+			Destination: destination,
+		},
+		resp,
+	)
+	return resp.Amount, err
+}
+
+func (cli *Client) Allowance(
+	ctx context.Context,
+	owner string,
+	spender string,
+	asset ids.ID,
+) (uint64, error) {
+	resp := new(controller.AllowanceReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"allowance",
+		&controller.AllowanceArgs{
+			Owner:   owner,
+			Spender: spender,
+			Asset:   asset,
+		},
+		resp,
+	)
+	return resp.Amount, err
+}
+
+// Fees returns the transfer fees of [asset] that can be claimed by its fee
+// recipient.
+func (cli *Client) Fees(ctx context.Context, asset ids.ID) (uint64, error) {
+	resp := new(controller.FeesReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"fees",
+		&controller.FeesArgs{
+			Asset: asset,
+		},
+		resp,
+	)
+	return resp.Amount, err
+}
+
+func (cli *Client) Frozen(ctx context.Context, asset ids.ID, addr string) (bool, error) {
+	resp := new(controller.FrozenReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"frozen",
+		&controller.FrozenArgs{
+			Asset:   asset,
+			Address: addr,
+		},
+		resp,
+	)
+	return resp.Frozen, err
+}
+
+func (cli *Client) HTLC(
+	ctx context.Context,
+	htlc ids.ID,
+) (bool, *controller.HTLCReply, error) {
+	resp := new(controller.HTLCReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"htlc",
+		&controller.HTLCArgs{
+			HTLC: htlc,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), controller.ErrHTLCNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+func (cli *Client) Vesting(
+	ctx context.Context,
+	vesting ids.ID,
+) (bool, *controller.VestingReply, error) {
+	resp := new(controller.VestingReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"vesting",
+		&controller.VestingArgs{
+			Vesting: vesting,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), controller.ErrVestingNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+func (cli *Client) Stream(
+	ctx context.Context,
+	stream ids.ID,
+) (bool, *controller.StreamReply, error) {
+	resp := new(controller.StreamReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"stream",
+		&controller.StreamArgs{
+			Stream: stream,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), controller.ErrStreamNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+func (cli *Client) Airdrop(
+	ctx context.Context,
+	airdrop ids.ID,
+) (bool, *controller.AirdropReply, error) {
+	resp := new(controller.AirdropReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"airdrop",
+		&controller.AirdropArgs{
+			Airdrop: airdrop,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), controller.ErrAirdropNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+func (cli *Client) AirdropClaimed(ctx context.Context, airdrop ids.ID, index uint64) (bool, error) {
+	resp := new(controller.AirdropClaimedReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"airdropClaimed",
+		&controller.AirdropClaimedArgs{
+			Airdrop: airdrop,
+			Index:   index,
+		},
+		resp,
+	)
+	return resp.Claimed, err
+}
+
+func (cli *Client) Collection(
+	ctx context.Context,
+	collection ids.ID,
+) (bool, []byte, string, uint64, error) {
+	resp := new(controller.CollectionReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"collection",
+		&controller.CollectionArgs{
+			Collection: collection,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), controller.ErrCollectionNotFound.Error()):
+		return false, nil, "", 0, nil
+	case err != nil:
+		return false, nil, "", 0, err
+	}
+	return true, resp.Metadata, resp.Owner, resp.Supply, nil
+}
+
+func (cli *Client) NFT(
+	ctx context.Context,
+	collection ids.ID,
+	id uint64,
+) (bool, string, []byte, error) {
+	resp := new(controller.NFTReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"nft",
+		&controller.NFTArgs{
+			Collection: collection,
+			ID:         id,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), controller.ErrNFTNotFound.Error()):
+		return false, "", nil, nil
+	case err != nil:
+		return false, "", nil, err
+	}
+	return true, resp.Owner, resp.Metadata, nil
+}
+
+func (cli *Client) NFTs(ctx context.Context, collection ids.ID, start uint64) ([]uint64, error) {
+	resp := new(controller.NFTsReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"nfts",
+		&controller.NFTsArgs{
+			Collection: collection,
+			Start:      start,
+		},
+		resp,
+	)
+	return resp.IDs, err
+}
+
+func (cli *Client) Pool(
+	ctx context.Context,
+	pool ids.ID,
+) (bool, *controller.PoolReply, error) {
+	resp := new(controller.PoolReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"pool",
+		&controller.PoolArgs{
+			Pool: pool,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), controller.ErrPoolNotFound.Error()):
+		return false, nil, nil
+	case err != nil:
+		return false, nil, err
+	}
+	return true, resp, nil
+}
+
+func (cli *Client) Quote(
+	ctx context.Context,
+	pool ids.ID,
+	in ids.ID,
+	value uint64,
+) (*controller.QuoteReply, error) {
+	resp := new(controller.QuoteReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"quote",
+		&controller.QuoteArgs{
+			Pool:  pool,
+			In:    in,
+			Value: value,
+		},
+		resp,
+	)
+	return resp, err
+}
+
+func (cli *Client) Multisig(ctx context.Context, addr string) (bool, uint8, []string, error) {
+	resp := new(controller.MultisigReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"multisig",
+		&controller.MultisigArgs{
+			Address: addr,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), controller.ErrMultisigNotFound.Error()):
+		return false, 0, nil, nil
+	case err != nil:
+		return false, 0, nil, err
+	}
+	return true, resp.Threshold, resp.Signers, nil
+}
+
+func (cli *Client) SessionKey(
+	ctx context.Context,
+	addr string,
+	sessionKey string,
+) (bool, int64, []uint8, []*auth.Amount, error) {
+	resp := new(controller.SessionKeyReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"sessionKey",
+		&controller.SessionKeyArgs{
+			Address:    addr,
+			SessionKey: sessionKey,
+		},
+		resp,
+	)
+	switch {
+	// We use string parsing here because the JSON-RPC library we use may not
+	// allows us to perform errors.Is.
+	case err != nil && strings.Contains(err.Error(), controller.ErrSessionKeyNotFound.Error()):
+		return false, 0, nil, nil, nil
+	case err != nil:
+		return false, 0, nil, nil, err
+	}
+	return true, resp.Expiry, resp.Actions, resp.Limits, nil
+}
+
+func (cli *Client) Orders(ctx context.Context, pair string) ([]*orderbook.Order, error) {
+	resp := new(controller.OrdersReply)
+	err := cli.Requester.SendRequest(
+		ctx,
+		"orders",
+		&controller.OrdersArgs{
+			Pair: pair,
+		},
+		resp,
+	)
+	return resp.Orders, err
+}
@@ -2,12 +2,18 @@ package client
 
 import (
 	"context"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/client"
+	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/utils"
+
+	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/consts"
 )
 
 func (cli *Client) GenerateTransaction(
@@ -35,6 +41,44 @@ func (cli *Client) GenerateTransaction(
 		modifiers...)
 }
 
+// SponsorTransaction countersigns [tx] with [priv] so that the sponsor pays
+// its fees. [tx] must have been generated with an [auth.SponsoredFactory] that
+// names the public key of [priv] as the sponsor. It returns the maximum fee the
+// sponsor is charged.
+func (cli *Client) SponsorTransaction(
+	ctx context.Context,
+	tx *chain.Transaction,
+	priv crypto.PrivateKey,
+) (func(context.Context) error, *chain.Transaction, uint64, error) {
+	sponsored, ok := tx.Auth.(*auth.Sponsored)
+	if !ok {
+		return nil, nil, 0, auth.ErrNotSponsored
+	}
+	g, err := cli.Genesis(ctx)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	// Sign the same message as the actor
+	stx := chain.NewTx(tx.Base, tx.WarpMessage, tx.Action)
+	stx, err = stx.Sign(auth.NewSponsorFactory(priv, sponsored), consts.ActionRegistry, consts.AuthRegistry)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	maxUnits, err := stx.MaxUnits(g.Rules(time.Now().Unix()))
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	fee, err := math.Mul64(maxUnits, stx.Base.UnitPrice)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return func(ictx context.Context) error {
+		_, err := cli.SubmitTx(ictx, stx.Bytes())
+		return err
+	}, stx, fee, nil
+}
+
 func (cli *Client) WaitForBalance(
 	ctx context.Context,
 	addr string,
@@ -61,7 +105,7 @@ func (cli *Client) WaitForBalance(
 func (cli *Client) WaitForTransaction(ctx context.Context, txID ids.ID) (bool, error) {
 	var success bool
 	if err := client.Wait(ctx, func(ctx context.Context) (bool, error) {
-		found, isuccess, _, err := cli.Tx(ctx, txID)
+		found, isuccess, _, _, err := cli.Tx(ctx, txID)
 		if err != nil {
 			return false, err
 		}
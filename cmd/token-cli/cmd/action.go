@@ -2,16 +2,29 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
 	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/manifoldco/promptui"
 	"github.com/rafael-abuawad/samplevm/actions"
+	"github.com/rafael-abuawad/samplevm/auth"
 	"github.com/rafael-abuawad/samplevm/client"
 	"github.com/rafael-abuawad/samplevm/utils"
 	"github.com/spf13/cobra"
@@ -55,7 +68,25 @@ var transferCmd = &cobra.Command{
 		}
 
 		// Select amount
-		amount, err := promptAmount("amount", assetID, balance, nil)
+		amount, err := promptAmount(ctx, cli, "amount", assetID, balance, nil)
+		if err != nil {
+			return err
+		}
+		if err := printTransferFee(ctx, cli, assetID, amount); err != nil {
+			return err
+		}
+
+		// Add memo to transfer
+		promptText := promptui.Prompt{
+			Label: "memo (optional)",
+			Validate: func(input string) error {
+				if len(input) > actions.MaxMemoSize {
+					return errors.New("input too large")
+				}
+				return nil
+			},
+		}
+		memo, err := promptText.Run()
 		if err != nil {
 			return err
 		}
@@ -67,11 +98,20 @@ var transferCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.Transfer{
+		var action chain.Action = &actions.Transfer{
 			To:    recipient,
 			Asset: assetID,
 			Value: amount,
-		}, factory)
+		}
+		if len(memo) > 0 {
+			action = &actions.TransferWithMemo{
+				To:    recipient,
+				Asset: assetID,
+				Value: amount,
+				Memo:  []byte(memo),
+			}
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, action, factory)
 		if err != nil {
 			return err
 		}
@@ -87,26 +127,172 @@ var transferCmd = &cobra.Command{
 	},
 }
 
-var createAssetCmd = &cobra.Command{
-	Use: "create-asset",
+var batchTransferCmd = &cobra.Command{
+	Use: "batch-transfer",
 	RunE: func(*cobra.Command, []string) error {
 		ctx := context.Background()
-		_, _, factory, cli, err := defaultActor()
+		_, priv, factory, cli, err := defaultActor()
 		if err != nil {
 			return err
 		}
 
-		// Add metadata to token
-		promptText := promptui.Prompt{
-			Label: "metadata (can be changed later)",
-			Validate: func(input string) error {
-				if len(input) > actions.MaxMetadataSize {
-					return errors.New("input too large")
-				}
+		// Load entries
+		path, err := promptString("entries file (csv of address,assetID,amount)")
+		if err != nil {
+			return err
+		}
+		entries, err := readBatchEntries(ctx, cli, path)
+		if err != nil {
+			return err
+		}
+		if len(entries) > actions.MaxBatchTransferEntries {
+			return fmt.Errorf(
+				"%w: %d > %d",
+				actions.ErrTooManyEntries,
+				len(entries),
+				actions.MaxBatchTransferEntries,
+			)
+		}
+
+		// Ensure we have enough balance for all entries
+		totals := map[ids.ID]uint64{}
+		for _, entry := range entries {
+			total, err := smath.Add64(totals[entry.Asset], entry.Value)
+			if err != nil {
+				return err
+			}
+			totals[entry.Asset] = total
+		}
+		for assetID, total := range totals {
+			balance, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), assetID, true)
+			if balance == 0 || err != nil {
+				return err
+			}
+			if balance < total {
+				hutils.Outf(
+					"{{red}}need %s %s but only have %s{{/}}\n",
+					valueString(cli, assetID, total),
+					assetString(assetID),
+					valueString(cli, assetID, balance),
+				)
+				hutils.Outf("{{red}}exiting...{{/}}\n")
 				return nil
-			},
+			}
+			hutils.Outf(
+				"{{yellow}}sending:{{/}} %s %s\n",
+				valueString(cli, assetID, total),
+				assetString(assetID),
+			)
 		}
-		metadata, err := promptText.Run()
+		hutils.Outf("{{yellow}}recipients:{{/}} %d\n", len(entries))
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.BatchTransfer{
+			Entries: entries,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+// readBatchEntries parses a CSV file where each row is of the form
+// address,assetID,amount. An empty assetID refers to the native asset and
+// amounts are parsed using the decimals of each asset.
+func readBatchEntries(
+	ctx context.Context,
+	cli *client.Client,
+	path string,
+) ([]*actions.BatchTransferEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 3
+	r.TrimLeadingSpace = true
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*actions.BatchTransferEntry, 0, len(records))
+	for i, record := range records {
+		to, err := utils.ParseAddress(record[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", ErrInvalidEntry, i, err)
+		}
+		var assetID ids.ID
+		if len(record[1]) > 0 {
+			assetID, err = ids.FromString(record[1])
+			if err != nil {
+				return nil, fmt.Errorf("%w: row %d: %v", ErrInvalidEntry, i, err)
+			}
+		}
+		value, err := parseAmount(ctx, cli, assetID, record[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", ErrInvalidEntry, i, err)
+		}
+		entries = append(entries, &actions.BatchTransferEntry{
+			To:    to,
+			Asset: assetID,
+			Value: value,
+		})
+	}
+	return entries, nil
+}
+
+var approveCmd = &cobra.Command{
+	Use: "approve",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to approve
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+		if _, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), assetID, true); err != nil {
+			return err
+		}
+
+		// Select spender
+		spender, err := promptAddress("spender")
+		if err != nil {
+			return err
+		}
+		allowance, err := cli.Allowance(ctx, utils.Address(priv.PublicKey()), utils.Address(spender), assetID)
+		if err != nil {
+			return err
+		}
+		hutils.Outf(
+			"{{yellow}}current allowance:{{/}} %s %s\n",
+			valueString(cli, assetID, allowance),
+			assetString(assetID),
+		)
+
+		// Select amount
+		amount, err := promptAmount(ctx, cli, "allowance (0 to revoke)", assetID, consts.MaxUint64, nil)
 		if err != nil {
 			return err
 		}
@@ -118,8 +304,10 @@ var createAssetCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.CreateAsset{
-			Metadata: []byte(metadata),
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.Approve{
+			Spender: spender,
+			Asset:   assetID,
+			Value:   amount,
 		}, factory)
 		if err != nil {
 			return err
@@ -136,8 +324,8 @@ var createAssetCmd = &cobra.Command{
 	},
 }
 
-var mintAssetCmd = &cobra.Command{
-	Use: "mint-asset",
+var transferFromCmd = &cobra.Command{
+	Use: "transfer-from",
 	RunE: func(*cobra.Command, []string) error {
 		ctx := context.Background()
 		_, priv, factory, cli, err := defaultActor()
@@ -145,34 +333,34 @@ var mintAssetCmd = &cobra.Command{
 			return err
 		}
 
-		// Select token to mint
-		assetID, err := promptAsset("assetID", false)
+		// Select owner
+		owner, err := promptAddress("owner")
 		if err != nil {
 			return err
 		}
-		exists, metadata, supply, owner, warp, err := cli.Asset(ctx, assetID)
+
+		// Select token to send
+		assetID, err := promptAsset("assetID", true)
 		if err != nil {
 			return err
 		}
-		if !exists {
-			hutils.Outf("{{red}}%s does not exist{{/}}\n", assetID)
-			hutils.Outf("{{red}}exiting...{{/}}\n")
-			return nil
+		balance, _, err := getAssetInfo(ctx, cli, owner, assetID, true)
+		if balance == 0 || err != nil {
+			return err
 		}
-		if warp {
-			hutils.Outf("{{red}}cannot mint a warped asset{{/}}\n", assetID)
-			hutils.Outf("{{red}}exiting...{{/}}\n")
-			return nil
+		allowance, err := cli.Allowance(ctx, utils.Address(owner), utils.Address(priv.PublicKey()), assetID)
+		if err != nil {
+			return err
 		}
-		if owner != utils.Address(priv.PublicKey()) {
-			hutils.Outf("{{red}}%s is the owner of %s, you are not{{/}}\n", owner, assetID)
+		if allowance == 0 {
+			hutils.Outf("{{red}}you have no allowance from %s{{/}}\n", utils.Address(owner))
 			hutils.Outf("{{red}}exiting...{{/}}\n")
 			return nil
 		}
 		hutils.Outf(
-			"{{yellow}}metadata:{{/}} %s {{yellow}}supply:{{/}} %d\n",
-			string(metadata),
-			supply,
+			"{{yellow}}allowance:{{/}} %s %s\n",
+			valueString(cli, assetID, allowance),
+			assetString(assetID),
 		)
 
 		// Select recipient
@@ -182,10 +370,17 @@ var mintAssetCmd = &cobra.Command{
 		}
 
 		// Select amount
-		amount, err := promptAmount("amount", assetID, consts.MaxUint64-supply, nil)
+		max := balance
+		if allowance < max {
+			max = allowance
+		}
+		amount, err := promptAmount(ctx, cli, "amount", assetID, max, nil)
 		if err != nil {
 			return err
 		}
+		if err := printTransferFee(ctx, cli, assetID, amount); err != nil {
+			return err
+		}
 
 		// Confirm action
 		cont, err := promptContinue()
@@ -194,9 +389,10 @@ var mintAssetCmd = &cobra.Command{
 		}
 
 		// Generate transaction
-		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.MintAsset{
-			Asset: assetID,
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.TransferFrom{
+			Owner: owner,
 			To:    recipient,
+			Asset: assetID,
 			Value: amount,
 		}, factory)
 		if err != nil {
@@ -214,6 +410,2699 @@ var mintAssetCmd = &cobra.Command{
 	},
 }
 
+var createAssetCmd = &cobra.Command{
+	Use: "create-asset",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select how the token is displayed
+		symbol, err := promptSymbol("symbol")
+		if err != nil {
+			return err
+		}
+		decimals, err := promptDecimals(fmt.Sprintf("decimals (max %d)", actions.MaxDecimals))
+		if err != nil {
+			return err
+		}
+		promptText := promptui.Prompt{
+			Label: "name",
+			Validate: func(input string) error {
+				if len(input) == 0 {
+					return ErrInputEmpty
+				}
+				if len(input) > actions.MaxNameSize {
+					return errors.New("input too large")
+				}
+				return nil
+			},
+		}
+		name, err := promptText.Run()
+		if err != nil {
+			return err
+		}
+		promptText = promptui.Prompt{
+			Label: "uri (optional)",
+			Validate: func(input string) error {
+				if len(input) > actions.MaxURISize {
+					return errors.New("input too large")
+				}
+				return nil
+			},
+		}
+		uri, err := promptText.Run()
+		if err != nil {
+			return err
+		}
+		var uriHash ids.ID
+		if len(uri) > 0 {
+			promptText = promptui.Prompt{
+				Label: "uri hash (hex-encoded SHA-256 of the uri contents)",
+				Validate: func(input string) error {
+					if len(input) == 0 {
+						return ErrInputEmpty
+					}
+					_, err := parseHash(input)
+					return err
+				},
+			}
+			rawHash, err := promptText.Run()
+			if err != nil {
+				return err
+			}
+			uriHash, err = parseHash(strings.TrimSpace(rawHash))
+			if err != nil {
+				return err
+			}
+		}
+
+		// Add metadata to token
+		promptText = promptui.Prompt{
+			Label: "metadata (can be changed later)",
+			Validate: func(input string) error {
+				if len(input) > actions.MaxMetadataSize {
+					return errors.New("input too large")
+				}
+				return nil
+			},
+		}
+		metadata, err := promptText.Run()
+		if err != nil {
+			return err
+		}
+
+		// Select max supply
+		maxSupply, err := promptUint64("max supply (0 for no cap)")
+		if err != nil {
+			return err
+		}
+
+		// Select if the owner can freeze accounts and claw back funds
+		regulated, err := promptBool("regulated (cannot be changed later)")
+		if err != nil {
+			return err
+		}
+
+		// Select transfer fee
+		fee, err := promptUint64("transfer fee in basis points (0 for no fee)")
+		if err != nil {
+			return err
+		}
+		if fee >= actions.BasisPoints {
+			return ErrFeeTooLarge
+		}
+		var feeRecipient crypto.PublicKey
+		if fee > 0 {
+			feeRecipient, err = promptAddress("fee recipient")
+			if err != nil {
+				return err
+			}
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.CreateAsset{
+			Symbol:       symbol,
+			Decimals:     decimals,
+			Name:         []byte(name),
+			URI:          []byte(uri),
+			URIHash:      uriHash,
+			Metadata:     []byte(metadata),
+			MaxSupply:    maxSupply,
+			Regulated:    regulated,
+			TransferFee:  fee,
+			FeeRecipient: feeRecipient,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var mintAssetCmd = &cobra.Command{
+	Use: "mint-asset",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to mint
+		assetID, err := promptAsset("assetID", false)
+		if err != nil {
+			return err
+		}
+		exists, asset, err := cli.Asset(ctx, assetID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			hutils.Outf("{{red}}%s does not exist{{/}}\n", assetID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if asset.Warp {
+			hutils.Outf("{{red}}cannot mint a warped asset{{/}}\n", assetID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if asset.Owner != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the owner of %s, you are not{{/}}\n", asset.Owner, assetID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		hutils.Outf(
+			"{{yellow}}symbol:{{/}} %s {{yellow}}metadata:{{/}} %s {{yellow}}supply:{{/}} %s {{yellow}}max supply:{{/}} %s\n",
+			string(asset.Symbol),
+			string(asset.Metadata),
+			utils.FormatBalance(asset.Supply, asset.Decimals),
+			maxSupplyString(asset.MaxSupply, asset.Decimals),
+		)
+		mintable := consts.MaxUint64 - asset.Supply
+		if asset.MaxSupply > 0 {
+			mintable = asset.MaxSupply - asset.Supply
+		}
+		if mintable == 0 {
+			hutils.Outf("{{red}}%s has reached its max supply{{/}}\n", assetID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Select recipient
+		recipient, err := promptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := promptAmount(ctx, cli, "amount", assetID, mintable, nil)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.MintAsset{
+			Asset: assetID,
+			To:    recipient,
+			Value: amount,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var burnAssetCmd = &cobra.Command{
+	Use: "burn-asset",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to burn
+		assetID, err := promptAsset("assetID", false)
+		if err != nil {
+			return err
+		}
+		balance, sourceChainID, err := getAssetInfo(ctx, cli, priv.PublicKey(), assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+		if sourceChainID != ids.Empty {
+			hutils.Outf("{{red}}cannot burn a warped asset{{/}}\n")
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Select amount
+		amount, err := promptAmount(ctx, cli, "amount", assetID, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.BurnAsset{
+			Asset: assetID,
+			Value: amount,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var modifyAssetCmd = &cobra.Command{
+	Use: "modify-asset",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to modify
+		assetID, err := promptAsset("assetID", false)
+		if err != nil {
+			return err
+		}
+		exists, asset, err := cli.Asset(ctx, assetID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			hutils.Outf("{{red}}%s does not exist{{/}}\n", assetID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if asset.Warp {
+			hutils.Outf("{{red}}cannot modify a warped asset{{/}}\n")
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if asset.Owner != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the owner of %s, you are not{{/}}\n", asset.Owner, assetID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		hutils.Outf(
+			"{{yellow}}symbol:{{/}} %s {{yellow}}metadata:{{/}} %s {{yellow}}supply:{{/}} %s\n",
+			string(asset.Symbol),
+			string(asset.Metadata),
+			utils.FormatBalance(asset.Supply, asset.Decimals),
+		)
+
+		// Add new metadata to token
+		promptText := promptui.Prompt{
+			Label: "new metadata",
+			Validate: func(input string) error {
+				if len(input) > actions.MaxMetadataSize {
+					return errors.New("input too large")
+				}
+				return nil
+			},
+		}
+		newMetadata, err := promptText.Run()
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.ModifyAsset{
+			Asset:    assetID,
+			Metadata: []byte(newMetadata),
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var transferAssetOwnershipCmd = &cobra.Command{
+	Use: "transfer-asset-ownership",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to transfer
+		assetID, err := promptAsset("assetID", false)
+		if err != nil {
+			return err
+		}
+		exists, asset, err := cli.Asset(ctx, assetID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			hutils.Outf("{{red}}%s does not exist{{/}}\n", assetID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if asset.Warp {
+			hutils.Outf("{{red}}cannot transfer ownership of a warped asset{{/}}\n")
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if asset.Owner != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the owner of %s, you are not{{/}}\n", ownerString(asset.Owner), assetID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		hutils.Outf(
+			"{{yellow}}symbol:{{/}} %s {{yellow}}metadata:{{/}} %s {{yellow}}supply:{{/}} %s\n",
+			string(asset.Symbol),
+			string(asset.Metadata),
+			utils.FormatBalance(asset.Supply, asset.Decimals),
+		)
+
+		// Select new owner
+		renounce, err := promptBool("renounce ownership (supply will be fixed forever)")
+		if err != nil {
+			return err
+		}
+		var to crypto.PublicKey
+		if !renounce {
+			to, err = promptAddress("new owner")
+			if err != nil {
+				return err
+			}
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.TransferAssetOwnership{
+			Asset:    assetID,
+			To:       to,
+			Renounce: renounce,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var exportAssetCmd = &cobra.Command{
+	Use: "export-asset",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		currentChainID, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to export
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, sourceChainID, err := getAssetInfo(ctx, cli, priv.PublicKey(), assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select recipient
+		recipient, err := promptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := promptAmount(ctx, cli, "amount", assetID, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Determine return
+		var ret bool
+		if sourceChainID != ids.Empty {
+			ret = true
+		}
+
+		// Select reward
+		reward, err := promptAmount(ctx, cli, "reward", assetID, balance-amount, nil)
+		if err != nil {
+			return err
+		}
+
+		// Determine destination
+		destination := sourceChainID
+		if !ret {
+			destination, _, err = promptChain("destination", set.Set[ids.ID]{currentChainID: {}})
+			if err != nil {
+				return err
+			}
+		}
+
+		// Determine if swap in
+		swap, err := promptBool("swap on import")
+		if err != nil {
+			return err
+		}
+		var (
+			swapIn     uint64
+			assetOut   ids.ID
+			swapOut    uint64
+			swapExpiry int64
+		)
+		if swap {
+			swapIn, err = promptAmount(ctx, cli, "swap in", assetID, amount, nil)
+			if err != nil {
+				return err
+			}
+			assetOut, err = promptAsset("asset out (on destination)", true)
+			if err != nil {
+				return err
+			}
+			// [assetOut] lives on [destination], so we need to use its
+			// decimals on that chain.
+			uris, err := GetChain(destination)
+			if err != nil {
+				return err
+			}
+			if len(uris) == 0 {
+				return ErrNoChains
+			}
+			swapOut, err = promptAmount(
+				ctx,
+				client.New(uris[0]),
+				"swap out (on destination)",
+				assetOut,
+				consts.MaxUint64,
+				nil,
+			)
+			if err != nil {
+				return err
+			}
+			swapExpiry, err = promptTime("swap expiry")
+			if err != nil {
+				return err
+			}
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.ExportAsset{
+			To:          recipient,
+			Asset:       assetID,
+			Value:       amount,
+			Return:      ret,
+			Reward:      reward,
+			SwapIn:      swapIn,
+			AssetOut:    assetOut,
+			SwapOut:     swapOut,
+			SwapExpiry:  swapExpiry,
+			Destination: destination,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		if !success {
+			return ErrTxFailed
+		}
+
+		// Perform import
+		imp, err := promptBool("perform import on destination")
+		if err != nil {
+			return err
+		}
+		if imp {
+			uris, err := GetChain(destination)
+			if err != nil {
+				return err
+			}
+			dcli := client.New(uris[0])
+			if err := submitDummy(ctx, dcli, priv.PublicKey(), factory); err != nil {
+				return err
+			}
+			return performImport(ctx, cli, dcli, tx.ID(), factory)
+		}
+
+		// Ask if user would like to switch to destination chain
+		sw, err := promptBool("switch default chain to destination")
+		if err != nil {
+			return err
+		}
+		if !sw {
+			return nil
+		}
+		return StoreDefault(defaultChainKey, destination[:])
+	},
+}
+
+var importAssetCmd = &cobra.Command{
+	Use: "import-asset",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		currentChainID, _, factory, dcli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select source
+		_, uris, err := promptChain("sourceChainID", set.Set[ids.ID]{currentChainID: {}})
+		if err != nil {
+			return err
+		}
+		scli := client.New(uris[0])
+
+		// Perform import
+		return performImport(ctx, scli, dcli, ids.Empty, factory)
+	},
+}
+
+var createOrderCmd = &cobra.Command{
+	Use: "create-order",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select inbound token
+		inAssetID, err := promptAsset("in assetID", true)
+		if err != nil {
+			return err
+		}
+		if _, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), inAssetID, false); err != nil {
+			return err
+		}
+
+		// Select in tick
+		inTick, err := promptAmount(ctx, cli, "in tick", inAssetID, consts.MaxUint64, nil)
+		if err != nil {
+			return err
+		}
+
+		// Select outbound token
+		outAssetID, err := promptAsset("out assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), outAssetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select out tick
+		outTick, err := promptAmount(ctx, cli, "out tick", outAssetID, consts.MaxUint64, nil)
+		if err != nil {
+			return err
+		}
+
+		// Select supply
+		supply, err := promptAmount(
+			ctx,
+			cli,
+			"supply (must be multiple of out tick)",
+			outAssetID,
+			balance,
+			func(input uint64) error {
+				if input%outTick != 0 {
+					return ErrNotMultiple
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.CreateOrder{
+			In:      inAssetID,
+			InTick:  inTick,
+			Out:     outAssetID,
+			OutTick: outTick,
+			Supply:  supply,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var fillOrderCmd = &cobra.Command{
+	Use: "fill-order",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select inbound token
+		inAssetID, err := promptAsset("in assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), inAssetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select outbound token
+		outAssetID, err := promptAsset("out assetID", true)
+		if err != nil {
+			return err
+		}
+		if _, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), outAssetID, false); err != nil {
+			return err
+		}
+
+		// View orders
+		orders, err := cli.Orders(ctx, actions.PairID(inAssetID, outAssetID))
+		if err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			hutils.Outf("{{red}}no available orders{{/}}\n")
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		hutils.Outf("{{cyan}}available orders:{{/}} %d\n", len(orders))
+		max := 20
+		if len(orders) < max {
+			max = len(orders)
+		}
+		for i := 0; i < max; i++ {
+			order := orders[i]
+			hutils.Outf(
+				"%d) {{cyan}}Rate(in/out):{{/}} %.4f {{cyan}}InTick:{{/}} %s %s {{cyan}}OutTick:{{/}} %s %s {{cyan}}Remaining:{{/}} %s %s\n",
+				i,
+				float64(order.InTick)/float64(order.OutTick),
+				valueString(cli, inAssetID, order.InTick),
+				assetString(inAssetID),
+				valueString(cli, outAssetID, order.OutTick),
+				assetString(outAssetID),
+				valueString(cli, outAssetID, order.Remaining),
+				assetString(outAssetID),
+			)
+		}
+
+		// Select order
+		orderIndex, err := promptChoice("select order", max)
+		if err != nil {
+			return err
+		}
+		order := orders[orderIndex]
+
+		// Select input to trade
+		value, err := promptAmount(
+			ctx,
+			cli,
+			"value (must be multiple of in tick)",
+			inAssetID,
+			balance,
+			func(input uint64) error {
+				if input%order.InTick != 0 {
+					return ErrNotMultiple
+				}
+				multiples := input / order.InTick
+				requiredRemainder := order.OutTick * multiples
+				if requiredRemainder > order.Remaining {
+					return ErrInsufficientSupply
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		owner, err := utils.ParseAddress(order.Owner)
+		if err != nil {
+			return err
+		}
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.FillOrder{
+			Order: order.ID,
+			Owner: owner,
+			In:    inAssetID,
+			Out:   outAssetID,
+			Value: value,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var closeOrderCmd = &cobra.Command{
+	Use: "close-order",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select order
+		orderID, err := promptID("orderID")
+		if err != nil {
+			return err
+		}
+
+		// Select outbound token
+		outAssetID, err := promptAsset("out assetID", true)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.CloseOrder{
+			Order: orderID,
+			Out:   outAssetID,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var lockHTLCCmd = &cobra.Command{
+	Use: "lock-htlc",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to lock
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select recipient
+		recipient, err := promptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := promptAmount(ctx, cli, "amount", assetID, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Select hash lock
+		promptText := promptui.Prompt{
+			Label: "sha256 hash of secret (hex, leave empty to generate a new secret)",
+			Validate: func(input string) error {
+				if len(input) == 0 {
+					return nil
+				}
+				_, err := parseHash(input)
+				return err
+			},
+		}
+		rawHash, err := promptText.Run()
+		if err != nil {
+			return err
+		}
+		var hash ids.ID
+		if len(rawHash) == 0 {
+			secret := make([]byte, 32)
+			if _, err := rand.Read(secret); err != nil {
+				return err
+			}
+			hash = hutils.ToID(secret)
+			hutils.Outf("{{yellow}}secret:{{/}} %s\n", hex.EncodeToString(secret))
+			hutils.Outf("{{red}}do not share the secret until the counterparty has locked their funds{{/}}\n")
+		} else {
+			hash, err = parseHash(rawHash)
+			if err != nil {
+				return err
+			}
+		}
+		hutils.Outf("{{yellow}}hash:{{/}} %s\n", hex.EncodeToString(hash[:]))
+
+		// Select expiry
+		expiry, err := promptTime("expiry (unix seconds)")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.LockHTLC{
+			To:     recipient,
+			Asset:  assetID,
+			Value:  amount,
+			Hash:   hash,
+			Expiry: expiry,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var claimHTLCCmd = &cobra.Command{
+	Use: "claim-htlc",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select HTLC
+		htlcID, err := promptID("htlcID")
+		if err != nil {
+			return err
+		}
+		htlc, err := getHTLCInfo(ctx, cli, htlcID)
+		if htlc == nil || err != nil {
+			return err
+		}
+		if htlc.To != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the recipient of %s, you are not{{/}}\n", htlc.To, htlcID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Reveal preimage
+		promptText := promptui.Prompt{
+			Label: "secret (hex)",
+			Validate: func(input string) error {
+				secret, err := hex.DecodeString(input)
+				if err != nil {
+					return err
+				}
+				if hutils.ToID(secret) != htlc.Hash {
+					return ErrWrongPreimage
+				}
+				return nil
+			},
+		}
+		rawSecret, err := promptText.Run()
+		if err != nil {
+			return err
+		}
+		secret, err := hex.DecodeString(rawSecret)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.ClaimHTLC{
+			HTLC:     htlcID,
+			Asset:    htlc.Asset,
+			Preimage: secret,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var refundHTLCCmd = &cobra.Command{
+	Use: "refund-htlc",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select HTLC
+		htlcID, err := promptID("htlcID")
+		if err != nil {
+			return err
+		}
+		htlc, err := getHTLCInfo(ctx, cli, htlcID)
+		if htlc == nil || err != nil {
+			return err
+		}
+		if htlc.Sender != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the sender of %s, you are not{{/}}\n", htlc.Sender, htlcID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if now := time.Now().Unix(); now < htlc.Expiry {
+			hutils.Outf("{{red}}%s expires in %s{{/}}\n", htlcID, time.Duration(htlc.Expiry-now)*time.Second)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.RefundHTLC{
+			HTLC:  htlcID,
+			Asset: htlc.Asset,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createVestingCmd = &cobra.Command{
+	Use: "create-vesting",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to vest
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select beneficiary
+		beneficiary, err := promptAddress("beneficiary")
+		if err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := promptAmount(ctx, cli, "amount", assetID, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Select schedule
+		start, err := promptTime("start (unix seconds)")
+		if err != nil {
+			return err
+		}
+		cliff, err := promptTime("cliff (seconds after start)")
+		if err != nil {
+			return err
+		}
+		duration, err := promptTime("duration (seconds after start)")
+		if err != nil {
+			return err
+		}
+		if duration <= 0 || cliff < 0 || cliff > duration {
+			return ErrInvalidSchedule
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.CreateVesting{
+			Beneficiary: beneficiary,
+			Asset:       assetID,
+			Value:       amount,
+			Start:       start,
+			Cliff:       cliff,
+			Duration:    duration,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var claimVestedCmd = &cobra.Command{
+	Use: "claim-vested",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select vesting schedule
+		vestingID, err := promptID("vestingID")
+		if err != nil {
+			return err
+		}
+		exists, vesting, err := cli.Vesting(ctx, vestingID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			hutils.Outf("{{red}}%s does not exist{{/}}\n", vestingID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if vesting.Beneficiary != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the beneficiary of %s, you are not{{/}}\n", vesting.Beneficiary, vestingID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		claimable := vesting.Vested - vesting.Claimed
+		hutils.Outf(
+			"{{yellow}}vested:{{/}} %s {{yellow}}unvested:{{/}} %s {{yellow}}claimable:{{/}} %s %s\n",
+			valueString(cli, vesting.Asset, vesting.Vested),
+			valueString(cli, vesting.Asset, vesting.Unvested),
+			valueString(cli, vesting.Asset, claimable),
+			assetString(vesting.Asset),
+		)
+		if claimable == 0 {
+			hutils.Outf("{{red}}nothing to claim{{/}}\n")
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.ClaimVested{
+			Vesting: vestingID,
+			Asset:   vesting.Asset,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var freezeAccountCmd = &cobra.Command{
+	Use: "freeze-account",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to freeze
+		assetID, err := promptAsset("assetID", false)
+		if err != nil {
+			return err
+		}
+		ok, err := checkRegulator(ctx, cli, priv.PublicKey(), assetID)
+		if !ok || err != nil {
+			return err
+		}
+
+		// Select account
+		account, err := promptAddress("account")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.FreezeAccount{
+			Asset:   assetID,
+			Account: account,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var unfreezeAccountCmd = &cobra.Command{
+	Use: "unfreeze-account",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to unfreeze
+		assetID, err := promptAsset("assetID", false)
+		if err != nil {
+			return err
+		}
+		ok, err := checkRegulator(ctx, cli, priv.PublicKey(), assetID)
+		if !ok || err != nil {
+			return err
+		}
+
+		// Select account
+		account, err := promptAddress("account")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.UnfreezeAccount{
+			Asset:   assetID,
+			Account: account,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var clawbackCmd = &cobra.Command{
+	Use: "clawback",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to claw back
+		assetID, err := promptAsset("assetID", false)
+		if err != nil {
+			return err
+		}
+		ok, err := checkRegulator(ctx, cli, priv.PublicKey(), assetID)
+		if !ok || err != nil {
+			return err
+		}
+
+		// Select account
+		from, err := promptAddress("from")
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, cli, from, assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select amount
+		amount, err := promptAmount(ctx, cli, "amount", assetID, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.Clawback{
+			Asset: assetID,
+			From:  from,
+			Value: amount,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var claimFeesCmd = &cobra.Command{
+	Use: "claim-fees",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to claim fees of
+		assetID, err := promptAsset("assetID", false)
+		if err != nil {
+			return err
+		}
+		exists, asset, err := cli.Asset(ctx, assetID)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			hutils.Outf("{{red}}%s does not exist{{/}}\n", assetID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if asset.Fee == 0 {
+			hutils.Outf("{{red}}%s has no transfer fee{{/}}\n", assetID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if asset.FeeRecipient != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the fee recipient of %s, you are not{{/}}\n", asset.FeeRecipient, assetID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		fees, err := cli.Fees(ctx, assetID)
+		if err != nil {
+			return err
+		}
+		hutils.Outf(
+			"{{yellow}}fees:{{/}} %s %s\n",
+			valueString(cli, assetID, fees),
+			assetString(assetID),
+		)
+		if fees == 0 {
+			hutils.Outf("{{red}}nothing to claim{{/}}\n")
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.ClaimFees{
+			Asset: assetID,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createCollectionCmd = &cobra.Command{
+	Use: "create-collection",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Add metadata to collection
+		metadata, err := promptMetadata("metadata")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.CreateCollection{
+			Metadata: metadata,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var mintNFTCmd = &cobra.Command{
+	Use: "mint-nft",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select collection
+		collectionID, err := promptID("collectionID")
+		if err != nil {
+			return err
+		}
+		exists, owner, err := getCollectionInfo(ctx, cli, collectionID)
+		if !exists || err != nil {
+			return err
+		}
+		if owner != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the owner of %s, you are not{{/}}\n", owner, collectionID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Select NFT ID
+		id, err := promptUint64("nft id")
+		if err != nil {
+			return err
+		}
+		exists, _, _, err = cli.NFT(ctx, collectionID, id)
+		if err != nil {
+			return err
+		}
+		if exists {
+			hutils.Outf("{{red}}%s/%d already exists{{/}}\n", collectionID, id)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Select recipient
+		recipient, err := promptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Add metadata to NFT
+		metadata, err := promptMetadata("metadata")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.MintNFT{
+			Collection: collectionID,
+			ID:         id,
+			To:         recipient,
+			Metadata:   metadata,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var transferNFTCmd = &cobra.Command{
+	Use: "transfer-nft",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select NFT
+		collectionID, err := promptID("collectionID")
+		if err != nil {
+			return err
+		}
+		id, err := promptUint64("nft id")
+		if err != nil {
+			return err
+		}
+		exists, owner, err := getNFTInfo(ctx, cli, collectionID, id)
+		if !exists || err != nil {
+			return err
+		}
+		if owner != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the owner of %s/%d, you are not{{/}}\n", owner, collectionID, id)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Select recipient
+		recipient, err := promptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.TransferNFT{
+			Collection: collectionID,
+			ID:         id,
+			To:         recipient,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var burnNFTCmd = &cobra.Command{
+	Use: "burn-nft",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select NFT
+		collectionID, err := promptID("collectionID")
+		if err != nil {
+			return err
+		}
+		id, err := promptUint64("nft id")
+		if err != nil {
+			return err
+		}
+		exists, owner, err := getNFTInfo(ctx, cli, collectionID, id)
+		if !exists || err != nil {
+			return err
+		}
+		if owner != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the owner of %s/%d, you are not{{/}}\n", owner, collectionID, id)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.BurnNFT{
+			Collection: collectionID,
+			ID:         id,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createAirdropCmd = &cobra.Command{
+	Use: "create-airdrop",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to airdrop
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Load tree (generated with "airdrop build")
+		path, err := promptString("airdrop file")
+		if err != nil {
+			return err
+		}
+		tree, err := loadAirdropTree(path)
+		if err != nil {
+			return err
+		}
+		hutils.Outf(
+			"{{yellow}}entries:{{/}} %d {{yellow}}total:{{/}} %s %s {{yellow}}root:{{/}} %s\n",
+			len(tree.Claims),
+			valueString(cli, assetID, tree.Total),
+			assetString(assetID),
+			tree.Root,
+		)
+		if tree.Total > balance {
+			return ErrInsufficientBalance
+		}
+
+		// Select expiry
+		expiry, err := promptTime("expiry (unix seconds)")
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.CreateAirdrop{
+			Asset:   assetID,
+			Value:   tree.Total,
+			Root:    tree.Root,
+			Entries: uint64(len(tree.Claims)),
+			Expiry:  expiry,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var claimAirdropCmd = &cobra.Command{
+	Use: "claim-airdrop",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select airdrop
+		airdropID, err := promptID("airdropID")
+		if err != nil {
+			return err
+		}
+		airdrop, err := getAirdropInfo(ctx, cli, airdropID)
+		if airdrop == nil || err != nil {
+			return err
+		}
+
+		// Find entry in tree
+		path, err := promptString("airdrop file")
+		if err != nil {
+			return err
+		}
+		tree, err := loadAirdropTree(path)
+		if err != nil {
+			return err
+		}
+		if tree.Root != airdrop.Root {
+			hutils.Outf("{{red}}%s is not the root of %s{{/}}\n", tree.Root, airdropID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		addr := utils.Address(priv.PublicKey())
+		claim, ok := tree.Claims[addr]
+		if !ok {
+			hutils.Outf("{{red}}%s is not eligible for %s{{/}}\n", addr, airdropID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		claimed, err := cli.AirdropClaimed(ctx, airdropID, claim.Index)
+		if err != nil {
+			return err
+		}
+		if claimed {
+			hutils.Outf("{{red}}%s already claimed %s{{/}}\n", addr, airdropID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		hutils.Outf(
+			"{{yellow}}claimable:{{/}} %s %s\n",
+			valueString(cli, airdrop.Asset, claim.Amount),
+			assetString(airdrop.Asset),
+		)
+		if err := printTransferFee(ctx, cli, airdrop.Asset, claim.Amount); err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.ClaimAirdrop{
+			Airdrop: airdropID,
+			Asset:   airdrop.Asset,
+			Index:   claim.Index,
+			Amount:  claim.Amount,
+			Proof:   claim.Proof,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var reclaimAirdropCmd = &cobra.Command{
+	Use: "reclaim-airdrop",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select airdrop
+		airdropID, err := promptID("airdropID")
+		if err != nil {
+			return err
+		}
+		airdrop, err := getAirdropInfo(ctx, cli, airdropID)
+		if airdrop == nil || err != nil {
+			return err
+		}
+		if airdrop.Creator != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the creator of %s, you are not{{/}}\n", airdrop.Creator, airdropID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if now := time.Now().Unix(); now < airdrop.Expiry {
+			hutils.Outf("{{red}}%s expires in %s{{/}}\n", airdropID, time.Duration(airdrop.Expiry-now)*time.Second)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.ReclaimAirdrop{
+			Airdrop: airdropID,
+			Asset:   airdrop.Asset,
+			Entries: airdrop.Entries,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createPoolCmd = &cobra.Command{
+	Use: "create-pool",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select assets
+		assetA, err := promptAsset("assetA", true)
+		if err != nil {
+			return err
+		}
+		if _, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), assetA, false); err != nil {
+			return err
+		}
+		assetB, err := promptAsset("assetB", true)
+		if err != nil {
+			return err
+		}
+		if assetA == assetB {
+			hutils.Outf("{{red}}cannot create a pool with the same asset twice{{/}}\n")
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if _, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), assetB, false); err != nil {
+			return err
+		}
+
+		// Select pool fee
+		fee, err := promptUint64("pool fee in basis points")
+		if err != nil {
+			return err
+		}
+		if fee >= actions.BasisPoints {
+			return ErrFeeTooLarge
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.CreatePool{
+			AssetA: assetA,
+			AssetB: assetB,
+			Fee:    fee,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var addLiquidityCmd = &cobra.Command{
+	Use: "add-liquidity",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select pool
+		poolID, err := promptID("poolID")
+		if err != nil {
+			return err
+		}
+		pool, err := getPoolInfo(ctx, cli, poolID)
+		if pool == nil || err != nil {
+			return err
+		}
+
+		// Select amounts
+		balanceA, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), pool.AssetA, true)
+		if balanceA == 0 || err != nil {
+			return err
+		}
+		amountA, err := promptAmount(ctx, cli, "max amount of assetA", pool.AssetA, balanceA, nil)
+		if err != nil {
+			return err
+		}
+		balanceB, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), pool.AssetB, true)
+		if balanceB == 0 || err != nil {
+			return err
+		}
+		amountB, err := promptAmount(ctx, cli, "max amount of assetB", pool.AssetB, balanceB, nil)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.AddLiquidity{
+			Pool:    poolID,
+			AssetA:  pool.AssetA,
+			AssetB:  pool.AssetB,
+			AmountA: amountA,
+			AmountB: amountB,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var removeLiquidityCmd = &cobra.Command{
+	Use: "remove-liquidity",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select pool
+		poolID, err := promptID("poolID")
+		if err != nil {
+			return err
+		}
+		pool, err := getPoolInfo(ctx, cli, poolID)
+		if pool == nil || err != nil {
+			return err
+		}
+
+		// Select shares
+		balance, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), poolID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+		shares, err := promptAmount(ctx, cli, "shares", poolID, balance, nil)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.RemoveLiquidity{
+			Pool:   poolID,
+			AssetA: pool.AssetA,
+			AssetB: pool.AssetB,
+			Shares: shares,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var swapCmd = &cobra.Command{
+	Use: "swap",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select pool
+		poolID, err := promptID("poolID")
+		if err != nil {
+			return err
+		}
+		pool, err := getPoolInfo(ctx, cli, poolID)
+		if pool == nil || err != nil {
+			return err
+		}
+
+		// Select asset to swap
+		in, err := promptAsset("in", true)
+		if err != nil {
+			return err
+		}
+		if in != pool.AssetA && in != pool.AssetB {
+			hutils.Outf("{{red}}%s is not in %s{{/}}\n", in, poolID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		balance, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), in, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+		value, err := promptAmount(ctx, cli, "amount", in, balance, nil)
+		if err != nil {
+			return err
+		}
+		quote, err := cli.Quote(ctx, poolID, in, value)
+		if err != nil {
+			return err
+		}
+		hutils.Outf(
+			"{{yellow}}quote:{{/}} %s %s {{yellow}}transfer fee:{{/}} %s %s\n",
+			valueString(cli, quote.Out, quote.AmountOut),
+			assetString(quote.Out),
+			valueString(cli, quote.Out, quote.Fee),
+			assetString(quote.Out),
+		)
+		if quote.AmountOut == 0 {
+			hutils.Outf("{{red}}amount is too small to swap{{/}}\n")
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Select slippage tolerance
+		slippage, err := promptUint64("slippage tolerance in basis points")
+		if err != nil {
+			return err
+		}
+		if slippage > actions.BasisPoints {
+			return ErrSlippageTooLarge
+		}
+		minOut := applySlippage(quote.AmountOut, slippage)
+		hutils.Outf(
+			"{{yellow}}minimum received:{{/}} %s %s\n",
+			valueString(cli, quote.Out, minOut),
+			assetString(quote.Out),
+		)
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.Swap{
+			Pool:   poolID,
+			In:     in,
+			Out:    quote.Out,
+			Value:  value,
+			MinOut: minOut,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createStreamCmd = &cobra.Command{
+	Use: "create-stream",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select token to stream
+		assetID, err := promptAsset("assetID", true)
+		if err != nil {
+			return err
+		}
+		balance, _, err := getAssetInfo(ctx, cli, priv.PublicKey(), assetID, true)
+		if balance == 0 || err != nil {
+			return err
+		}
+
+		// Select recipient
+		recipient, err := promptAddress("recipient")
+		if err != nil {
+			return err
+		}
+
+		// Select deposit and rate
+		amount, err := promptAmount(ctx, cli, "deposit", assetID, balance, nil)
+		if err != nil {
+			return err
+		}
+		rate, err := promptAmount(ctx, cli, "rate (per second)", assetID, amount, func(input uint64) error {
+			if input == 0 {
+				return ErrRateZero
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		duration := time.Duration((amount+rate-1)/rate) * time.Second
+		hutils.Outf("{{yellow}}duration:{{/}} %s\n", duration)
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.CreateStream{
+			Recipient: recipient,
+			Asset:     assetID,
+			Value:     amount,
+			Rate:      rate,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var withdrawStreamCmd = &cobra.Command{
+	Use: "withdraw-stream",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select stream
+		streamID, err := promptID("streamID")
+		if err != nil {
+			return err
+		}
+		stream, err := getStreamInfo(ctx, cli, streamID)
+		if stream == nil || err != nil {
+			return err
+		}
+		if stream.Recipient != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the recipient of %s, you are not{{/}}\n", stream.Recipient, streamID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		if stream.Withdrawable == 0 {
+			hutils.Outf("{{red}}nothing to withdraw{{/}}\n")
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.WithdrawStream{
+			Stream: streamID,
+			Asset:  stream.Asset,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var cancelStreamCmd = &cobra.Command{
+	Use: "cancel-stream",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, priv, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select stream
+		streamID, err := promptID("streamID")
+		if err != nil {
+			return err
+		}
+		stream, err := getStreamInfo(ctx, cli, streamID)
+		if stream == nil || err != nil {
+			return err
+		}
+		if stream.Sender != utils.Address(priv.PublicKey()) {
+			hutils.Outf("{{red}}%s is the sender of %s, you are not{{/}}\n", stream.Sender, streamID)
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+		recipient, err := utils.ParseAddress(stream.Recipient)
+		if err != nil {
+			return err
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.CancelStream{
+			Stream:    streamID,
+			Asset:     stream.Asset,
+			Recipient: recipient,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var createMultisigCmd = &cobra.Command{
+	Use: "create-multisig",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select signers
+		count, err := promptInt("number of signers")
+		if err != nil {
+			return err
+		}
+		if count > auth.MaxMultisigSigners {
+			return ErrTooManySigners
+		}
+		signers := make([]crypto.PublicKey, count)
+		seen := set.NewSet[crypto.PublicKey](count)
+		for i := 0; i < count; i++ {
+			signer, err := promptAddress(fmt.Sprintf("signer %d", i))
+			if err != nil {
+				return err
+			}
+			if seen.Contains(signer) {
+				return ErrDuplicate
+			}
+			seen.Add(signer)
+			signers[i] = signer
+		}
+		// Signers are registered in ascending order
+		sort.Slice(signers, func(i, j int) bool {
+			return bytes.Compare(signers[i][:], signers[j][:]) < 0
+		})
+
+		// Select threshold
+		threshold, err := promptInt("threshold")
+		if err != nil {
+			return err
+		}
+		if threshold > count {
+			return ErrThresholdTooLarge
+		}
+		create := &actions.CreateMultisig{
+			Threshold: uint8(threshold),
+			Signers:   signers,
+		}
+		hutils.Outf("{{yellow}}multisig address:{{/}} %s\n", utils.Address(create.Account()))
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, create, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+var registerSessionKeyCmd = &cobra.Command{
+	Use: "register-session-key",
+	RunE: func(*cobra.Command, []string) error {
+		ctx := context.Background()
+		_, _, factory, cli, err := defaultActor()
+		if err != nil {
+			return err
+		}
+
+		// Select session key
+		sessionKey, err := promptAddress("session key")
+		if err != nil {
+			return err
+		}
+
+		// Select expiry
+		expiry, err := promptTime("expiry (unix seconds)")
+		if err != nil {
+			return err
+		}
+
+		// Select allowed actions
+		count, err := promptInt("number of allowed actions")
+		if err != nil {
+			return err
+		}
+		if count > auth.MaxSessionKeyActions {
+			return actions.ErrTooManyEntries
+		}
+		allowed := make([]uint8, count)
+		seen := set.NewSet[uint8](count)
+		for i := 0; i < count; i++ {
+			typeID, err := promptChoice(fmt.Sprintf("action type %d", i), int(consts.MaxUint8)+1)
+			if err != nil {
+				return err
+			}
+			if seen.Contains(uint8(typeID)) {
+				return ErrDuplicate
+			}
+			seen.Add(uint8(typeID))
+			allowed[i] = uint8(typeID)
+		}
+
+		// Select spending limits
+		count, err = promptChoice("number of spending limits", auth.MaxSessionKeyLimits+1)
+		if err != nil {
+			return err
+		}
+		limits := make([]*auth.Amount, count)
+		assets := set.NewSet[ids.ID](count)
+		for i := 0; i < count; i++ {
+			assetID, err := promptAsset(fmt.Sprintf("limit %d asset", i), true)
+			if err != nil {
+				return err
+			}
+			if assets.Contains(assetID) {
+				return ErrDuplicate
+			}
+			assets.Add(assetID)
+			value, err := promptAmount(ctx, cli, fmt.Sprintf("limit %d amount", i), assetID, consts.MaxUint64, nil)
+			if err != nil {
+				return err
+			}
+			limits[i] = &auth.Amount{Asset: assetID, Value: value}
+		}
+
+		// Confirm action
+		cont, err := promptContinue()
+		if !cont || err != nil {
+			return err
+		}
+
+		// Generate transaction
+		submit, tx, _, err := cli.GenerateTransaction(ctx, nil, &actions.RegisterSessionKey{
+			SessionKey: sessionKey,
+			Expiry:     expiry,
+			Actions:    allowed,
+			Limits:     limits,
+		}, factory)
+		if err != nil {
+			return err
+		}
+		if err := submit(ctx); err != nil {
+			return err
+		}
+		success, err := cli.WaitForTransaction(ctx, tx.ID())
+		if err != nil {
+			return err
+		}
+		printStatus(tx.ID(), success)
+		return nil
+	},
+}
+
+func performImport(
+	ctx context.Context,
+	scli *client.Client,
+	dcli *client.Client,
+	exportTxID ids.ID,
+	factory chain.AuthFactory,
+) error {
+	// Select TxID (if not provided)
+	var err error
+	if exportTxID == ids.Empty {
+		exportTxID, err = promptID("export txID")
+		if err != nil {
+			return err
+		}
+	}
+
+	// Generate warp signature (as long as >= 80% stake)
+	var (
+		msg                     *warp.Message
+		subnetWeight, sigWeight uint64
+	)
+	for ctx.Err() == nil {
+		msg, subnetWeight, sigWeight, err = scli.GenerateAggregateWarpSignature(ctx, exportTxID)
+		if sigWeight >= (subnetWeight*4)/5 && err == nil {
+			break
+		}
+		if err == nil {
+			hutils.Outf(
+				"{{yellow}}waiting for signature weight:{{/}} %d {{yellow}}observed:{{/}} %d\n",
+				subnetWeight,
+				sigWeight,
+			)
+		} else {
+			hutils.Outf("{{red}}encountered error:{{/}} %v\n", err)
+		}
+		cont, err := promptBool("try again")
+		if err != nil {
+			return err
+		}
+		if !cont {
+			hutils.Outf("{{red}}exiting...{{/}}\n")
+			return nil
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	wt, err := actions.UnmarshalWarpTransfer(msg.UnsignedMessage.Payload)
+	if err != nil {
+		return err
+	}
+	outputAssetID := wt.Asset
+	if !wt.Return {
+		outputAssetID = actions.ImportedAssetID(wt.Asset, msg.SourceChainID)
+	}
+	hutils.Outf(
+		"{{yellow}}to:{{/}} %s {{yellow}}source assetID:{{/}} %s {{yellow}}symbol:{{/}} %s {{yellow}}output assetID:{{/}} %s {{yellow}}value:{{/}} %s {{yellow}}reward:{{/}} %s {{yellow}}return:{{/}} %t\n",
+		utils.Address(wt.To),
+		assetString(wt.Asset),
+		string(wt.Symbol),
+		assetString(outputAssetID),
+		utils.FormatBalance(wt.Value, wt.Decimals),
+		utils.FormatBalance(wt.Reward, wt.Decimals),
+		wt.Return,
+	)
+	if wt.SwapIn > 0 {
+		hutils.Outf(
+			"{{yellow}}swap in:{{/}} %s {{yellow}}asset out:{{/}} %s {{yellow}}swap out:{{/}} %s {{yellow}}swap expiry:{{/}} %d\n",
+			utils.FormatBalance(wt.SwapIn, wt.Decimals),
+			assetString(wt.AssetOut),
+			valueString(dcli, wt.AssetOut, wt.SwapOut),
+			wt.SwapExpiry,
+		)
+	}
+	hutils.Outf(
+		"{{yellow}}signature weight:{{/}} %d {{yellow}}total weight:{{/}} %d\n",
+		sigWeight,
+		subnetWeight,
+	)
+
+	// Select fill
+	var fill bool
+	if wt.SwapIn > 0 {
+		fill, err = promptBool("fill")
+		if err != nil {
+			return err
+		}
+	}
+	if !fill && wt.SwapExpiry > time.Now().Unix() {
+		return ErrMustFill
+	}
+
+	// Generate transaction
+	submit, tx, _, err := dcli.GenerateTransaction(ctx, msg, &actions.ImportAsset{
+		Fill: fill,
+	}, factory)
+	if err != nil {
+		return err
+	}
+	if err := submit(ctx); err != nil {
+		return err
+	}
+	success, err := dcli.WaitForTransaction(ctx, tx.ID())
+	if err != nil {
+		return err
+	}
+	printStatus(tx.ID(), success)
+	return nil
+}
+
 func submitDummy(
 	ctx context.Context,
 	cli *client.Client,
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/ids"
+	smath "github.com/ava-labs/avalanchego/utils/math"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/rafael-abuawad/samplevm/actions"
+	"github.com/rafael-abuawad/samplevm/utils"
+)
+
+// AirdropClaim is everything an account needs to submit a [ClaimAirdrop].
+type AirdropClaim struct {
+	Index  uint64   `json:"index"`
+	Amount uint64   `json:"amount"`
+	Proof  []ids.ID `json:"proof"`
+}
+
+// AirdropTree is the output of "airdrop build". It should be shared with all
+// recipients so they can claim their entry.
+type AirdropTree struct {
+	Root   ids.ID                   `json:"root"`
+	Total  uint64                   `json:"total"`
+	Claims map[string]*AirdropClaim `json:"claims"`
+}
+
+var airdropCmd = &cobra.Command{
+	Use: "airdrop",
+	RunE: func(*cobra.Command, []string) error {
+		return ErrMissingSubcommand
+	},
+}
+
+var buildAirdropCmd = &cobra.Command{
+	Use:   "build [csv file] [output file]",
+	Short: "Builds a Merkle tree (and proofs) from a CSV of address,amount entries",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		f, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r := csv.NewReader(f)
+		r.FieldsPerRecord = 2
+		r.TrimLeadingSpace = true
+		records, err := r.ReadAll()
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return ErrInputEmpty
+		}
+		if len(records) > actions.MaxAirdropEntries {
+			return fmt.Errorf("%w: %d > %d", actions.ErrTooManyEntries, len(records), actions.MaxAirdropEntries)
+		}
+
+		tree := &AirdropTree{Claims: make(map[string]*AirdropClaim, len(records))}
+		leaves := make([]ids.ID, len(records))
+		for i, record := range records {
+			address := strings.TrimSpace(record[0])
+			account, err := utils.ParseAddress(address)
+			if err != nil {
+				return fmt.Errorf("%w: line %d", err, i+1)
+			}
+			if _, ok := tree.Claims[address]; ok {
+				return fmt.Errorf("%w: %s", ErrDuplicate, address)
+			}
+			amount, err := utils.ParseBalance(strings.TrimSpace(record[1]), airdropDecimals)
+			if err != nil {
+				return fmt.Errorf("%w: line %d", err, i+1)
+			}
+			if amount == 0 {
+				return fmt.Errorf("%w: line %d", ErrInvalidEntry, i+1)
+			}
+			tree.Total, err = smath.Add64(tree.Total, amount)
+			if err != nil {
+				return err
+			}
+			leaves[i] = actions.AirdropLeaf(uint64(i), account, amount)
+			tree.Claims[address] = &AirdropClaim{Index: uint64(i), Amount: amount}
+		}
+		root, proofs := actions.BuildAirdropTree(leaves)
+		tree.Root = root
+		for i, record := range records {
+			tree.Claims[strings.TrimSpace(record[0])].Proof = proofs[i]
+		}
+
+		b, err := json.Marshal(tree)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(args[1], b, fsModeWrite); err != nil {
+			return err
+		}
+		color.Green(
+			"built airdrop with %d entries (root=%s total=%d) and saved to %s",
+			len(records),
+			tree.Root,
+			tree.Total,
+			args[1],
+		)
+		return nil
+	},
+}
+
+func loadAirdropTree(path string) (*AirdropTree, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tree AirdropTree
+	if err := json.Unmarshal(b, &tree); err != nil {
+		return nil, err
+	}
+	return &tree, nil
+}
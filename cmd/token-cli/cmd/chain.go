@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"reflect"
-	"strconv"
 	"strings"
 	"time"
 
@@ -19,7 +18,6 @@ import (
 	"github.com/rafael-abuawad/samplevm/actions"
 	"github.com/rafael-abuawad/samplevm/auth"
 	"github.com/rafael-abuawad/samplevm/client"
-	"github.com/rafael-abuawad/samplevm/consts"
 	tutils "github.com/rafael-abuawad/samplevm/utils"
 )
 
@@ -214,25 +212,168 @@ var watchChainCmd = &cobra.Command{
 					status = "✅"
 					switch action := tx.Action.(type) {
 					case *actions.CreateAsset:
-						summaryStr = fmt.Sprintf("assetID: %s metadata:%s", tx.ID(), string(action.Metadata))
+						summaryStr = fmt.Sprintf("assetID: %s symbol:%s decimals:%d metadata:%s maxSupply:%s regulated:%t fee:%dbps", tx.ID(), string(action.Symbol), action.Decimals, string(action.Metadata), maxSupplyString(action.MaxSupply, action.Decimals), action.Regulated, action.TransferFee)
 
 					case *actions.MintAsset:
-						amountStr := strconv.FormatUint(action.Value, 10)
-						assetStr := action.Asset.String()
-						if action.Asset == ids.Empty {
-							amountStr = utils.FormatBalance(action.Value)
-							assetStr = consts.Symbol
+						summaryStr = fmt.Sprintf("%s %s -> %s", valueString(cli, action.Asset, action.Value), assetString(action.Asset), tutils.Address(action.To))
+
+					case *actions.BurnAsset:
+						summaryStr = fmt.Sprintf("%s %s -> 🔥", valueString(cli, action.Asset, action.Value), assetString(action.Asset))
+
+					case *actions.ModifyAsset:
+						summaryStr = fmt.Sprintf("assetID: %s metadata:%s", action.Asset, string(action.Metadata))
+
+					case *actions.ExportAsset:
+						summaryStr = fmt.Sprintf("%s %s -> %s (destination: %s return: %t)", valueString(cli, action.Asset, action.Value), assetString(action.Asset), tutils.Address(action.To), action.Destination, action.Return)
+
+					case *actions.ImportAsset:
+						wm := tx.WarpMessage
+						wt, _ := actions.UnmarshalWarpTransfer(wm.Payload)
+						summaryStr = fmt.Sprintf("source: %s | ", wm.SourceChainID)
+						if wt.Return {
+							summaryStr += fmt.Sprintf("%s %s -> %s (return: %t)", tutils.FormatBalance(wt.Value, wt.Decimals), assetString(wt.Asset), tutils.Address(wt.To), wt.Return)
+						} else {
+							summaryStr += fmt.Sprintf("%s %s (original: %s) -> %s (return: %t)", tutils.FormatBalance(wt.Value, wt.Decimals), actions.ImportedAssetID(wt.Asset, wm.SourceChainID), wt.Asset, tutils.Address(wt.To), wt.Return)
+						}
+						if wt.Reward > 0 {
+							summaryStr += fmt.Sprintf(" | reward: %s", tutils.FormatBalance(wt.Reward, wt.Decimals))
+						}
+						if wt.SwapIn > 0 {
+							summaryStr += fmt.Sprintf(" | swap in: %s swap out: %s %s expiry: %d fill: %t", tutils.FormatBalance(wt.SwapIn, wt.Decimals), valueString(cli, wt.AssetOut, wt.SwapOut), assetString(wt.AssetOut), wt.SwapExpiry, action.Fill)
 						}
-						summaryStr = fmt.Sprintf("%s %s -> %s", amountStr, assetStr, tutils.Address(action.To))
+
+					case *actions.CreateOrder:
+						summaryStr = fmt.Sprintf("%s %s -> %s %s (supply: %s %s)", valueString(cli, action.In, action.InTick), assetString(action.In), valueString(cli, action.Out, action.OutTick), assetString(action.Out), valueString(cli, action.Out, action.Supply), assetString(action.Out))
+
+					case *actions.FillOrder:
+						or, _ := actions.UnmarshalOrderResult(result.Output)
+						summaryStr = fmt.Sprintf("%s %s -> %s %s (remaining: %s %s)", valueString(cli, action.In, or.In), assetString(action.In), valueString(cli, action.Out, or.Out), assetString(action.Out), valueString(cli, action.Out, or.Remaining), assetString(action.Out))
+
+					case *actions.CloseOrder:
+						summaryStr = fmt.Sprintf("orderID: %s", action.Order)
+
+					case *actions.TransferAssetOwnership:
+						if action.Renounce {
+							summaryStr = fmt.Sprintf("assetID: %s renounced", action.Asset)
+						} else {
+							summaryStr = fmt.Sprintf("assetID: %s -> %s", action.Asset, tutils.Address(action.To))
+						}
+
+					case *actions.BatchTransfer:
+						summaryStr = fmt.Sprintf("%d transfers", len(action.Entries))
+
+					case *actions.Approve:
+						summaryStr = fmt.Sprintf("%s %s -> spender: %s", valueString(cli, action.Asset, action.Value), assetString(action.Asset), tutils.Address(action.Spender))
+
+					case *actions.TransferFrom:
+						summaryStr = fmt.Sprintf("%s %s %s -> %s", valueString(cli, action.Asset, action.Value), assetString(action.Asset), tutils.Address(action.Owner), tutils.Address(action.To))
+
+					case *actions.LockHTLC:
+						summaryStr = fmt.Sprintf("%s %s -> %s (hash: %x expiry: %d)", valueString(cli, action.Asset, action.Value), assetString(action.Asset), tutils.Address(action.To), action.Hash[:], action.Expiry)
+
+					case *actions.ClaimHTLC:
+						summaryStr = fmt.Sprintf("htlcID: %s (preimage: %x)", action.HTLC, action.Preimage)
+
+					case *actions.RefundHTLC:
+						summaryStr = fmt.Sprintf("htlcID: %s", action.HTLC)
+
+					case *actions.CreateVesting:
+						summaryStr = fmt.Sprintf("%s %s -> %s (start: %d cliff: %ds duration: %ds)", valueString(cli, action.Asset, action.Value), assetString(action.Asset), tutils.Address(action.Beneficiary), action.Start, action.Cliff, action.Duration)
+
+					case *actions.ClaimVested:
+						summaryStr = fmt.Sprintf("vestingID: %s", action.Vesting)
+
+					case *actions.FreezeAccount:
+						summaryStr = fmt.Sprintf("assetID: %s frozen: %s", action.Asset, tutils.Address(action.Account))
+
+					case *actions.UnfreezeAccount:
+						summaryStr = fmt.Sprintf("assetID: %s unfrozen: %s", action.Asset, tutils.Address(action.Account))
+
+					case *actions.Clawback:
+						summaryStr = fmt.Sprintf("%s %s %s -> owner", valueString(cli, action.Asset, action.Value), assetString(action.Asset), tutils.Address(action.From))
+
+					case *actions.ClaimFees:
+						summaryStr = fmt.Sprintf("assetID: %s", action.Asset)
+
+					case *actions.CreateCollection:
+						summaryStr = fmt.Sprintf("collectionID: %s metadata:%s", tx.ID(), string(action.Metadata))
+
+					case *actions.MintNFT:
+						summaryStr = fmt.Sprintf("%s/%d -> %s (metadata: %s)", action.Collection, action.ID, tutils.Address(action.To), string(action.Metadata))
+
+					case *actions.TransferNFT:
+						summaryStr = fmt.Sprintf("%s/%d -> %s", action.Collection, action.ID, tutils.Address(action.To))
+
+					case *actions.BurnNFT:
+						summaryStr = fmt.Sprintf("%s/%d -> 🔥", action.Collection, action.ID)
+
+					case *actions.CreateAirdrop:
+						summaryStr = fmt.Sprintf("airdropID: %s %s %s (root: %s entries: %d expiry: %d)", tx.ID(), valueString(cli, action.Asset, action.Value), assetString(action.Asset), action.Root, action.Entries, action.Expiry)
+
+					case *actions.ClaimAirdrop:
+						summaryStr = fmt.Sprintf("airdropID: %s index: %d %s %s", action.Airdrop, action.Index, valueString(cli, action.Asset, action.Amount), assetString(action.Asset))
+
+					case *actions.ReclaimAirdrop:
+						summaryStr = fmt.Sprintf("airdropID: %s", action.Airdrop)
+
+					case *actions.CreatePool:
+						summaryStr = fmt.Sprintf("poolID: %s %s/%s fee:%dbps", tx.ID(), assetString(action.AssetA), assetString(action.AssetB), action.Fee)
+
+					case *actions.AddLiquidity:
+						lr, _ := actions.UnmarshalLiquidityResult(result.Output)
+						summaryStr = fmt.Sprintf("%s %s + %s %s -> %d shares of %s", valueString(cli, action.AssetA, lr.AmountA), assetString(action.AssetA), valueString(cli, action.AssetB, lr.AmountB), assetString(action.AssetB), lr.Shares, action.Pool)
+
+					case *actions.RemoveLiquidity:
+						lr, _ := actions.UnmarshalLiquidityResult(result.Output)
+						summaryStr = fmt.Sprintf("%d shares of %s -> %s %s + %s %s", lr.Shares, action.Pool, valueString(cli, action.AssetA, lr.AmountA), assetString(action.AssetA), valueString(cli, action.AssetB, lr.AmountB), assetString(action.AssetB))
+
+					case *actions.Swap:
+						sr, _ := actions.UnmarshalSwapResult(result.Output)
+						summaryStr = fmt.Sprintf("%s %s -> %s %s (pool: %s)", valueString(cli, action.In, action.Value), assetString(action.In), valueString(cli, action.Out, sr.AmountOut), assetString(action.Out), action.Pool)
+						if sr.Fee > 0 {
+							summaryStr += fmt.Sprintf(" (fee: %s)", valueString(cli, action.Out, sr.Fee))
+						}
+
+					case *actions.CreateStream:
+						summaryStr = fmt.Sprintf("%s %s -> %s (streamID: %s rate: %s/s)", valueString(cli, action.Asset, action.Value), assetString(action.Asset), tutils.Address(action.Recipient), tx.ID(), valueString(cli, action.Asset, action.Rate))
+
+					case *actions.WithdrawStream:
+						summaryStr = fmt.Sprintf("streamID: %s", action.Stream)
+
+					case *actions.CancelStream:
+						summaryStr = fmt.Sprintf("streamID: %s", action.Stream)
+
+					case *actions.CreateMultisig:
+						summaryStr = fmt.Sprintf("multisig: %s threshold: %d/%d", tutils.Address(action.Account()), action.Threshold, len(action.Signers))
+
+					case *actions.RegisterSessionKey:
+						summaryStr = fmt.Sprintf("session key: %s expiry: %d actions: %d limits: %d", tutils.Address(action.SessionKey), action.Expiry, len(action.Actions), len(action.Limits))
+
+					case *actions.Sequence:
+						steps := make([]string, len(action.Actions))
+						for i, step := range action.Actions {
+							steps[i] = fmt.Sprintf("%s (stepID: %s)", reflect.TypeOf(step), actions.SequenceStepID(tx.ID(), i))
+						}
+						summaryStr = strings.Join(steps, " | ")
 
 					case *actions.Transfer:
-						amountStr := strconv.FormatUint(action.Value, 10)
-						assetStr := action.Asset.String()
-						if action.Asset == ids.Empty {
-							amountStr = utils.FormatBalance(action.Value)
-							assetStr = consts.Symbol
+						summaryStr = fmt.Sprintf("%s %s -> %s", valueString(cli, action.Asset, action.Value), assetString(action.Asset), tutils.Address(action.To))
+						if tr, _ := actions.UnmarshalTransferResult(result.Output); tr != nil && tr.Fee > 0 {
+							summaryStr += fmt.Sprintf(" (fee: %s)", valueString(cli, action.Asset, tr.Fee))
 						}
-						summaryStr = fmt.Sprintf("%s %s -> %s", amountStr, assetStr, tutils.Address(action.To))
+
+					case *actions.TransferWithMemo:
+						summaryStr = fmt.Sprintf("%s %s -> %s (memo: %s)", valueString(cli, action.Asset, action.Value), assetString(action.Asset), tutils.Address(action.To), string(action.Memo))
+						if tr, _ := actions.UnmarshalTransferResult(result.Output); tr != nil && tr.Fee > 0 {
+							summaryStr += fmt.Sprintf(" (fee: %s)", valueString(cli, action.Asset, tr.Fee))
+						}
+					}
+				} else if sequence, ok := tx.Action.(*actions.Sequence); ok {
+					// The last output of a failed sequence is the output of the
+					// step that failed.
+					if sr, err := actions.UnmarshalSequenceResult(result.Output); err == nil && len(sr.Outputs) > 0 {
+						step := len(sr.Outputs) - 1
+						summaryStr = fmt.Sprintf("step %d (%s) failed: %s", step, reflect.TypeOf(sequence.Actions[step]), string(sr.Outputs[step]))
 					}
 				}
 				utils.Outf(
@@ -16,4 +16,15 @@ var (
 	ErrNoKeys              = errors.New("no available keys")
 	ErrNoChains            = errors.New("no available chains")
 	ErrTxFailed            = errors.New("tx failed")
+	ErrInvalidEntry        = errors.New("invalid entry")
+	ErrWrongPreimage       = errors.New("wrong preimage")
+	ErrInvalidSchedule     = errors.New("invalid schedule")
+	ErrFeeTooLarge         = errors.New("fee is too large")
+	ErrSlippageTooLarge    = errors.New("slippage is too large")
+	ErrRateZero            = errors.New("rate is zero")
+	ErrInvalidSymbol       = errors.New("symbol must be uppercase alphanumeric")
+	ErrDecimalsTooLarge    = errors.New("decimals are too large")
+	ErrInvalidKeyType      = errors.New("invalid key type")
+	ErrTooManySigners      = errors.New("too many signers")
+	ErrThresholdTooLarge   = errors.New("threshold exceeds number of signers")
 )
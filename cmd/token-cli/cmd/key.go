@@ -21,10 +21,20 @@ var keyCmd = &cobra.Command{
 }
 
 var genKeyCmd = &cobra.Command{
-	Use: "generate",
-	RunE: func(*cobra.Command, []string) error {
+	Use: "generate [ed25519|secp256k1|p256]",
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) > 1 {
+			return ErrInvalidArgs
+		}
+		return nil
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		keyType := ed25519KeyType
+		if len(args) == 1 {
+			keyType = args[0]
+		}
 		// TODO: encrypt key
-		priv, err := crypto.GeneratePrivateKey()
+		priv, err := GenerateKey(keyType)
 		if err != nil {
 			return err
 		}
@@ -36,7 +46,8 @@ var genKeyCmd = &cobra.Command{
 			return err
 		}
 		color.Green(
-			"created address %s",
+			"created %s address %s",
+			priv.Type(),
 			utils.Address(publicKey),
 		)
 		return nil
@@ -56,10 +67,11 @@ var importKeyCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		if err := StoreKey(priv); err != nil {
+		key := NewED25519Key(priv)
+		if err := StoreKey(key); err != nil {
 			return err
 		}
-		publicKey := priv.PublicKey()
+		publicKey := key.PublicKey()
 		if err := StoreDefault(defaultKeyKey, publicKey[:]); err != nil {
 			return err
 		}
@@ -99,10 +111,11 @@ var setKeyCmd = &cobra.Command{
 				return err
 			}
 			hutils.Outf(
-				"%d) {{cyan}}address:{{/}} %s {{cyan}}balance:{{/}} %s TKN\n",
+				"%d) {{cyan}}address:{{/}} %s {{cyan}}type:{{/}} %s {{cyan}}balance:{{/}} %s TKN\n",
 				i,
 				address,
-				valueString(ids.Empty, balance),
+				keys[i].Type(),
+				valueString(cli, ids.Empty, balance),
 			)
 		}
 
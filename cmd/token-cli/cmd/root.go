@@ -26,6 +26,7 @@ var (
 	hideTxs         bool
 	randomRecipient bool
 	maxTxBacklog    int
+	airdropDecimals uint8
 
 	rootCmd = &cobra.Command{
 		Use:        "token-cli",
@@ -42,6 +43,7 @@ func init() {
 		chainCmd,
 		actionCmd,
 		spamCmd,
+		airdropCmd,
 	)
 	rootCmd.PersistentFlags().StringVar(
 		&dbPath,
@@ -103,8 +105,55 @@ func init() {
 	// actions
 	actionCmd.AddCommand(
 		transferCmd,
+		batchTransferCmd,
 		createAssetCmd,
 		mintAssetCmd,
+		burnAssetCmd,
+		modifyAssetCmd,
+		importAssetCmd,
+		exportAssetCmd,
+		createOrderCmd,
+		fillOrderCmd,
+		closeOrderCmd,
+		transferAssetOwnershipCmd,
+		approveCmd,
+		transferFromCmd,
+		lockHTLCCmd,
+		claimHTLCCmd,
+		refundHTLCCmd,
+		createVestingCmd,
+		claimVestedCmd,
+		freezeAccountCmd,
+		unfreezeAccountCmd,
+		clawbackCmd,
+		claimFeesCmd,
+		createCollectionCmd,
+		mintNFTCmd,
+		transferNFTCmd,
+		burnNFTCmd,
+		createAirdropCmd,
+		claimAirdropCmd,
+		reclaimAirdropCmd,
+		createPoolCmd,
+		addLiquidityCmd,
+		removeLiquidityCmd,
+		swapCmd,
+		createStreamCmd,
+		withdrawStreamCmd,
+		cancelStreamCmd,
+		createMultisigCmd,
+		registerSessionKeyCmd,
+	)
+
+	// airdrop
+	buildAirdropCmd.PersistentFlags().Uint8Var(
+		&airdropDecimals,
+		"decimals",
+		0,
+		"decimals of the airdropped asset (used to parse amounts)",
+	)
+	airdropCmd.AddCommand(
+		buildAirdropCmd,
 	)
 
 	// spam
@@ -103,7 +103,7 @@ var runSpamCmd = &cobra.Command{
 				"%d) {{cyan}}address:{{/}} %s {{cyan}}balance:{{/}} %s TKN\n",
 				i,
 				address,
-				valueString(ids.Empty, balance),
+				valueString(cli, ids.Empty, balance),
 			)
 		}
 		keyIndex, err := promptChoice("select root key", len(keys))
@@ -112,7 +112,7 @@ var runSpamCmd = &cobra.Command{
 		}
 		key := keys[keyIndex]
 		balance := balances[keyIndex]
-		factory := auth.NewED25519Factory(key)
+		factory := key.Factory()
 
 		// Distribute funds
 		numAccounts, err := promptInt("number of accounts")
@@ -123,7 +123,7 @@ var runSpamCmd = &cobra.Command{
 		distAmount := (balance - witholding) / uint64(numAccounts)
 		hutils.Outf(
 			"{{yellow}}distributing funds to each account:{{/}} %s %s\n",
-			valueString(ids.Empty, distAmount),
+			valueString(cli, ids.Empty, distAmount),
 			assetString(ids.Empty),
 		)
 		accounts := make([]crypto.PrivateKey, numAccounts)
@@ -395,7 +395,7 @@ var runSpamCmd = &cobra.Command{
 		}
 		hutils.Outf(
 			"{{yellow}}returned funds:{{/}} %s %s\n",
-			valueString(ids.Empty, returnedBalance),
+			valueString(cli, ids.Empty, returnedBalance),
 			assetString(ids.Empty),
 		)
 		return nil
@@ -1,13 +1,22 @@
 package cmd
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"errors"
+	"math/big"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/hypersdk/chain"
 	"github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/utils"
+
+	"github.com/rafael-abuawad/samplevm/auth"
+	tutils "github.com/rafael-abuawad/samplevm/utils"
 )
 
 const (
@@ -40,7 +49,145 @@ func GetDefault(key string) ([]byte, error) {
 	return v, nil
 }
 
-func StoreKey(privateKey crypto.PrivateKey) error {
+const (
+	ed25519KeyType   = "ed25519"
+	secp256k1KeyType = "secp256k1"
+	p256KeyType      = "p256"
+
+	// P-256 private keys have the same length as secp256k1 private keys, so
+	// they are stored with a [p256KeyTag] prefix.
+	p256KeyTag        = 0x1
+	p256PrivateKeyLen = 32
+)
+
+// Key is a private key stored by the CLI. Exactly one of [ed25519],
+// [secp256k1] and [p256] is set.
+type Key struct {
+	ed25519   crypto.PrivateKey
+	secp256k1 *secp256k1.PrivateKey
+	p256      *ecdsa.PrivateKey
+}
+
+func NewED25519Key(priv crypto.PrivateKey) *Key {
+	return &Key{ed25519: priv}
+}
+
+func NewSECP256K1Key(priv *secp256k1.PrivateKey) *Key {
+	return &Key{secp256k1: priv}
+}
+
+func NewP256Key(priv *ecdsa.PrivateKey) *Key {
+	return &Key{p256: priv}
+}
+
+// GenerateKey creates a new private key of type [keyType].
+func GenerateKey(keyType string) (*Key, error) {
+	switch keyType {
+	case ed25519KeyType:
+		priv, err := crypto.GeneratePrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		return NewED25519Key(priv), nil
+	case secp256k1KeyType:
+		factory := secp256k1.Factory{}
+		priv, err := factory.NewPrivateKey()
+		if err != nil {
+			return nil, err
+		}
+		return NewSECP256K1Key(priv), nil
+	case p256KeyType:
+		priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		return NewP256Key(priv), nil
+	default:
+		return nil, ErrInvalidKeyType
+	}
+}
+
+// parseKey parses a private key stored by [StoreKey]. Keys are distinguished
+// by their length, so keys stored before secp256k1 support are still valid.
+func parseKey(b []byte) (*Key, error) {
+	switch len(b) {
+	case crypto.PrivateKeyLen:
+		return NewED25519Key(crypto.PrivateKey(b)), nil
+	case secp256k1.PrivateKeyLen:
+		factory := secp256k1.Factory{}
+		priv, err := factory.ToPrivateKey(b)
+		if err != nil {
+			return nil, err
+		}
+		return NewSECP256K1Key(priv), nil
+	case 1 + p256PrivateKeyLen:
+		if b[0] != p256KeyTag {
+			return nil, ErrInvalidKeyType
+		}
+		curve := elliptic.P256()
+		d := new(big.Int).SetBytes(b[1:])
+		if d.Sign() == 0 || d.Cmp(curve.Params().N) >= 0 {
+			return nil, ErrInvalidKeyType
+		}
+		x, y := curve.ScalarBaseMult(b[1:])
+		return NewP256Key(&ecdsa.PrivateKey{
+			PublicKey: ecdsa.PublicKey{Curve: curve, X: x, Y: y},
+			D:         d,
+		}), nil
+	default:
+		return nil, ErrInvalidKeyType
+	}
+}
+
+func (k *Key) Type() string {
+	switch {
+	case k.secp256k1 != nil:
+		return secp256k1KeyType
+	case k.p256 != nil:
+		return p256KeyType
+	default:
+		return ed25519KeyType
+	}
+}
+
+// PublicKey returns the actor of [k].
+func (k *Key) PublicKey() crypto.PublicKey {
+	switch {
+	case k.secp256k1 != nil:
+		return tutils.SECP256K1Actor(k.secp256k1.PublicKey().Bytes())
+	case k.p256 != nil:
+		return tutils.P256Actor(elliptic.MarshalCompressed(k.p256.Curve, k.p256.X, k.p256.Y))
+	default:
+		return k.ed25519.PublicKey()
+	}
+}
+
+func (k *Key) Factory() chain.AuthFactory {
+	switch {
+	case k.secp256k1 != nil:
+		return auth.NewSECP256K1Factory(k.secp256k1)
+	case k.p256 != nil:
+		return auth.NewP256Factory(k.p256)
+	default:
+		return auth.NewED25519Factory(k.ed25519)
+	}
+}
+
+func (k *Key) Bytes() []byte {
+	switch {
+	case k.secp256k1 != nil:
+		return k.secp256k1.Bytes()
+	case k.p256 != nil:
+		b := make([]byte, 1+p256PrivateKeyLen)
+		b[0] = p256KeyTag
+		k.p256.D.FillBytes(b[1:])
+		return b
+	default:
+		return k.ed25519[:]
+	}
+}
+
+func StoreKey(privateKey *Key) error {
 	publicKey := privateKey.PublicKey()
 	k := make([]byte, 1+crypto.PublicKeyLen)
 	k[0] = keyPrefix
@@ -52,32 +199,36 @@ func StoreKey(privateKey crypto.PrivateKey) error {
 	if has {
 		return ErrDuplicate
 	}
-	return db.Put(k, privateKey[:])
+	return db.Put(k, privateKey.Bytes())
 }
 
-func GetKey(publicKey crypto.PublicKey) (crypto.PrivateKey, error) {
+func GetKey(publicKey crypto.PublicKey) (*Key, error) {
 	k := make([]byte, 1+crypto.PublicKeyLen)
 	k[0] = keyPrefix
 	copy(k[1:], publicKey[:])
 	v, err := db.Get(k)
 	if errors.Is(err, database.ErrNotFound) {
-		return crypto.EmptyPrivateKey, nil
+		return nil, nil
 	}
 	if err != nil {
-		return crypto.EmptyPrivateKey, err
+		return nil, err
 	}
-	return crypto.PrivateKey(v), nil
+	return parseKey(v)
 }
 
-func GetKeys() ([]crypto.PrivateKey, error) {
+func GetKeys() ([]*Key, error) {
 	iter := db.NewIteratorWithPrefix([]byte{keyPrefix})
 	defer iter.Release()
 
-	privateKeys := []crypto.PrivateKey{}
+	privateKeys := []*Key{}
 	for iter.Next() {
 		// It is safe to use these bytes directly because the database copies the
 		// iterator value for us.
-		privateKeys = append(privateKeys, crypto.PrivateKey(iter.Value()))
+		privateKey, err := parseKey(iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		privateKeys = append(privateKeys, privateKey)
 	}
 	return privateKeys, iter.Error()
 }
@@ -2,19 +2,24 @@ package cmd
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/hypersdk/chain"
 	hconsts "github.com/ava-labs/hypersdk/consts"
 	"github.com/ava-labs/hypersdk/crypto"
 	hutils "github.com/ava-labs/hypersdk/utils"
 	"github.com/manifoldco/promptui"
-	"github.com/rafael-abuawad/samplevm/auth"
+	"github.com/rafael-abuawad/samplevm/actions"
 	"github.com/rafael-abuawad/samplevm/client"
 	"github.com/rafael-abuawad/samplevm/consts"
+	"github.com/rafael-abuawad/samplevm/controller"
 	"github.com/rafael-abuawad/samplevm/utils"
 )
 
@@ -54,6 +59,74 @@ func promptString(label string) (string, error) {
 	return strings.TrimSpace(text), err
 }
 
+func promptMetadata(label string) ([]byte, error) {
+	promptText := promptui.Prompt{
+		Label: label,
+		Validate: func(input string) error {
+			if len(input) > actions.MaxMetadataSize {
+				return errors.New("input too large")
+			}
+			return nil
+		},
+	}
+	metadata, err := promptText.Run()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(metadata), nil
+}
+
+func promptSymbol(label string) ([]byte, error) {
+	promptText := promptui.Prompt{
+		Label: label,
+		Validate: func(input string) error {
+			if len(input) == 0 {
+				return ErrInputEmpty
+			}
+			if len(input) > actions.MaxSymbolSize {
+				return errors.New("input too large")
+			}
+			if !actions.ValidSymbol([]byte(input)) {
+				return ErrInvalidSymbol
+			}
+			return nil
+		},
+	}
+	symbol, err := promptText.Run()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(strings.TrimSpace(symbol)), nil
+}
+
+func promptDecimals(label string) (uint8, error) {
+	promptText := promptui.Prompt{
+		Label: label,
+		Validate: func(input string) error {
+			if len(input) == 0 {
+				return ErrInputEmpty
+			}
+			decimals, err := strconv.ParseUint(input, 10, 8)
+			if err != nil {
+				return err
+			}
+			if decimals > actions.MaxDecimals {
+				return ErrDecimalsTooLarge
+			}
+			return nil
+		},
+	}
+	rawDecimals, err := promptText.Run()
+	if err != nil {
+		return 0, err
+	}
+	decimals, err := strconv.ParseUint(strings.TrimSpace(rawDecimals), 10, 8)
+	if err != nil {
+		return 0, err
+	}
+	return uint8(decimals), nil
+}
+
 func promptAsset(label string, allowNative bool) (ids.ID, error) {
 	text := fmt.Sprintf("%s (use TKN for native token)", label)
 	if !allowNative {
@@ -91,24 +164,24 @@ func promptAsset(label string, allowNative bool) (ids.ID, error) {
 }
 
 func promptAmount(
+	ctx context.Context,
+	cli *client.Client,
 	label string,
 	assetID ids.ID,
 	balance uint64,
 	f func(input uint64) error,
 ) (uint64, error) {
+	decimals, err := cli.Decimals(ctx, assetID)
+	if err != nil {
+		return 0, err
+	}
 	promptText := promptui.Prompt{
 		Label: label,
 		Validate: func(input string) error {
 			if len(input) == 0 {
 				return ErrInputEmpty
 			}
-			var amount uint64
-			var err error
-			if assetID == ids.Empty {
-				amount, err = hutils.ParseBalance(input)
-			} else {
-				amount, err = strconv.ParseUint(input, 10, 64)
-			}
+			amount, err := utils.ParseBalance(input, decimals)
 			if err != nil {
 				return err
 			}
@@ -125,14 +198,42 @@ func promptAmount(
 	if err != nil {
 		return 0, err
 	}
-	rawAmount = strings.TrimSpace(rawAmount)
-	var amount uint64
+	return utils.ParseBalance(strings.TrimSpace(rawAmount), decimals)
+}
+
+// printTransferFee prints how much of [amount] the recipient of a transfer of
+// [assetID] receives once the transfer fee of [assetID] is withheld.
+func printTransferFee(ctx context.Context, cli *client.Client, assetID ids.ID, amount uint64) error {
 	if assetID == ids.Empty {
-		amount, err = hutils.ParseBalance(rawAmount)
-	} else {
-		amount, err = strconv.ParseUint(rawAmount, 10, 64)
+		// The native asset never has a transfer fee
+		return nil
+	}
+	_, asset, err := cli.Asset(ctx, assetID)
+	if err != nil {
+		return err
+	}
+	if asset == nil || asset.Fee == 0 {
+		return nil
+	}
+	withheld := actions.TransferFee(amount, asset.Fee)
+	hutils.Outf(
+		"{{yellow}}fee:{{/}} %s %s {{yellow}}recipient receives:{{/}} %s %s\n",
+		valueString(cli, assetID, withheld),
+		assetString(assetID),
+		valueString(cli, assetID, amount-withheld),
+		assetString(assetID),
+	)
+	return nil
+}
+
+// parseAmount parses [raw] as a decimal balance using the decimals of
+// [assetID].
+func parseAmount(ctx context.Context, cli *client.Client, assetID ids.ID, raw string) (uint64, error) {
+	decimals, err := cli.Decimals(ctx, assetID)
+	if err != nil {
+		return 0, err
 	}
-	return amount, err
+	return utils.ParseBalance(raw, decimals)
 }
 
 func promptInt(
@@ -162,6 +263,27 @@ func promptInt(
 	return strconv.Atoi(rawAmount)
 }
 
+func promptUint64(
+	label string,
+) (uint64, error) {
+	promptText := promptui.Prompt{
+		Label: label,
+		Validate: func(input string) error {
+			if len(input) == 0 {
+				return ErrInputEmpty
+			}
+			_, err := strconv.ParseUint(input, 10, 64)
+			return err
+		},
+	}
+	rawAmount, err := promptText.Run()
+	if err != nil {
+		return 0, err
+	}
+	rawAmount = strings.TrimSpace(rawAmount)
+	return strconv.ParseUint(rawAmount, 10, 64)
+}
+
 func promptChoice(label string, max int) (int, error) {
 	promptText := promptui.Prompt{
 		Label: label,
@@ -320,12 +442,14 @@ func promptChain(label string, excluded set.Set[ids.ID]) (ids.ID, []string, erro
 	return chainID, chains[chainID], nil
 }
 
-func valueString(assetID ids.ID, value uint64) string {
-	if assetID == ids.Empty {
-		return hutils.FormatBalance(value)
+// valueString formats [value] using the decimals of [assetID]. If the
+// decimals of [assetID] can't be fetched, [value] is printed in raw units.
+func valueString(cli *client.Client, assetID ids.ID, value uint64) string {
+	decimals, err := cli.Decimals(context.Background(), assetID)
+	if err != nil {
+		return strconv.FormatUint(value, 10)
 	}
-	// Custom assets are denoted in raw units
-	return strconv.FormatUint(value, 10)
+	return utils.FormatBalance(value, decimals)
 }
 
 func assetString(assetID ids.ID) string {
@@ -343,6 +467,59 @@ func printStatus(txID ids.ID, success bool) {
 	hutils.Outf("%s {{yellow}}txID:{{/}} %s\n", status, txID)
 }
 
+// maxSupplyString returns "none" for assets that do not have a supply cap.
+func maxSupplyString(maxSupply uint64, decimals uint8) string {
+	if maxSupply == 0 {
+		return "none"
+	}
+	return utils.FormatBalance(maxSupply, decimals)
+}
+
+// ownerString returns "renounced" for assets whose ownership has been
+// given up (owner is the empty public key).
+func ownerString(owner string) string {
+	if owner == utils.Address(crypto.EmptyPublicKey) {
+		return "renounced"
+	}
+	return owner
+}
+
+// checkRegulator returns true if [publicKey] can freeze accounts and claw
+// back funds for [assetID].
+func checkRegulator(
+	ctx context.Context,
+	cli *client.Client,
+	publicKey crypto.PublicKey,
+	assetID ids.ID,
+) (bool, error) {
+	exists, asset, err := cli.Asset(ctx, assetID)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		hutils.Outf("{{red}}%s does not exist{{/}}\n", assetID)
+		hutils.Outf("{{red}}exiting...{{/}}\n")
+		return false, nil
+	}
+	if !asset.Regulated {
+		hutils.Outf("{{red}}%s is not regulated{{/}}\n", assetID)
+		hutils.Outf("{{red}}exiting...{{/}}\n")
+		return false, nil
+	}
+	if asset.Owner != utils.Address(publicKey) {
+		hutils.Outf("{{red}}%s is the owner of %s, you are not{{/}}\n", ownerString(asset.Owner), assetID)
+		hutils.Outf("{{red}}exiting...{{/}}\n")
+		return false, nil
+	}
+	hutils.Outf(
+		"{{yellow}}symbol:{{/}} %s {{yellow}}metadata:{{/}} %s {{yellow}}supply:{{/}} %s\n",
+		string(asset.Symbol),
+		string(asset.Metadata),
+		utils.FormatBalance(asset.Supply, asset.Decimals),
+	)
+	return true, nil
+}
+
 func getAssetInfo(
 	ctx context.Context,
 	cli *client.Client,
@@ -352,7 +529,7 @@ func getAssetInfo(
 ) (uint64, ids.ID, error) {
 	var sourceChainID ids.ID
 	if assetID != ids.Empty {
-		exists, metadata, supply, _, warp, err := cli.Asset(ctx, assetID)
+		exists, asset, err := cli.Asset(ctx, assetID)
 		if err != nil {
 			return 0, ids.Empty, err
 		}
@@ -361,22 +538,37 @@ func getAssetInfo(
 			hutils.Outf("{{red}}exiting...{{/}}\n")
 			return 0, ids.Empty, nil
 		}
-		if warp {
-			sourceChainID = ids.ID(metadata[hconsts.IDLen:])
-			sourceAssetID := ids.ID(metadata[:hconsts.IDLen])
+		if asset.Warp {
+			sourceChainID = ids.ID(asset.Metadata[hconsts.IDLen:])
+			sourceAssetID := ids.ID(asset.Metadata[:hconsts.IDLen])
 			hutils.Outf(
-				"{{yellow}}sourceChainID:{{/}} %s {{yellow}}sourceAssetID:{{/}} %s {{yellow}}supply:{{/}} %d\n",
+				"{{yellow}}symbol:{{/}} %s {{yellow}}sourceChainID:{{/}} %s {{yellow}}sourceAssetID:{{/}} %s {{yellow}}supply:{{/}} %s\n",
+				string(asset.Symbol),
 				sourceChainID,
 				sourceAssetID,
-				supply,
+				utils.FormatBalance(asset.Supply, asset.Decimals),
 			)
 		} else {
 			hutils.Outf(
-				"{{yellow}}metadata:{{/}} %s {{yellow}}supply:{{/}} %d {{yellow}}warp:{{/}} %t\n",
-				string(metadata),
-				supply,
-				warp,
+				"{{yellow}}symbol:{{/}} %s {{yellow}}name:{{/}} %s {{yellow}}decimals:{{/}} %d {{yellow}}metadata:{{/}} %s {{yellow}}supply:{{/}} %s {{yellow}}max supply:{{/}} %s {{yellow}}owner:{{/}} %s {{yellow}}warp:{{/}} %t {{yellow}}regulated:{{/}} %t {{yellow}}transfer fee:{{/}} %d bps\n",
+				string(asset.Symbol),
+				string(asset.Name),
+				asset.Decimals,
+				string(asset.Metadata),
+				utils.FormatBalance(asset.Supply, asset.Decimals),
+				maxSupplyString(asset.MaxSupply, asset.Decimals),
+				ownerString(asset.Owner),
+				asset.Warp,
+				asset.Regulated,
+				asset.Fee,
 			)
+			if len(asset.URI) > 0 {
+				hutils.Outf(
+					"{{yellow}}uri:{{/}} %s {{yellow}}uri hash:{{/}} %s\n",
+					string(asset.URI),
+					asset.URIHash,
+				)
+			}
 		}
 	}
 	if !checkBalance {
@@ -395,37 +587,40 @@ func getAssetInfo(
 	}
 	hutils.Outf(
 		"{{yellow}}balance:{{/}} %s %s\n",
-		valueString(assetID, balance),
+		valueString(cli, assetID, balance),
 		assetString(assetID),
 	)
 	return balance, sourceChainID, nil
 }
 
-func defaultActor() (ids.ID, crypto.PrivateKey, *auth.ED25519Factory, *client.Client, error) {
+func defaultActor() (ids.ID, *Key, chain.AuthFactory, *client.Client, error) {
 	priv, err := GetDefaultKey()
 	if err != nil {
-		return ids.Empty, crypto.EmptyPrivateKey, nil, nil, err
+		return ids.Empty, nil, nil, nil, err
 	}
 	chainID, uris, err := GetDefaultChain()
 	if err != nil {
-		return ids.Empty, crypto.EmptyPrivateKey, nil, nil, err
+		return ids.Empty, nil, nil, nil, err
 	}
 	// For [defaultActor], we always send requests to the first returned URI.
-	return chainID, priv, auth.NewED25519Factory(priv), client.New(uris[0]), nil
+	return chainID, priv, priv.Factory(), client.New(uris[0]), nil
 }
 
-func GetDefaultKey() (crypto.PrivateKey, error) {
+func GetDefaultKey() (*Key, error) {
 	v, err := GetDefault(defaultKeyKey)
 	if err != nil {
-		return crypto.EmptyPrivateKey, err
+		return nil, err
 	}
 	if len(v) == 0 {
-		return crypto.EmptyPrivateKey, ErrNoKeys
+		return nil, ErrNoKeys
 	}
 	publicKey := crypto.PublicKey(v)
 	priv, err := GetKey(publicKey)
 	if err != nil {
-		return crypto.EmptyPrivateKey, err
+		return nil, err
+	}
+	if priv == nil {
+		return nil, ErrNoKeys
 	}
 	hutils.Outf("{{yellow}}address:{{/}} %s\n", utils.Address(publicKey))
 	return priv, nil
@@ -457,3 +652,174 @@ func CloseDatabase() error {
 	}
 	return nil
 }
+
+// parseHash parses a hex-encoded SHA-256 hash.
+func parseHash(input string) (ids.ID, error) {
+	b, err := hex.DecodeString(input)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return ids.ToID(b)
+}
+
+func getHTLCInfo(
+	ctx context.Context,
+	cli *client.Client,
+	htlcID ids.ID,
+) (*controller.HTLCReply, error) {
+	exists, htlc, err := cli.HTLC(ctx, htlcID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		hutils.Outf("{{red}}%s does not exist{{/}}\n", htlcID)
+		hutils.Outf("{{red}}exiting...{{/}}\n")
+		return nil, nil
+	}
+	hutils.Outf(
+		"{{yellow}}sender:{{/}} %s {{yellow}}to:{{/}} %s {{yellow}}value:{{/}} %s %s {{yellow}}expiry:{{/}} %s\n",
+		htlc.Sender,
+		htlc.To,
+		valueString(cli, htlc.Asset, htlc.Value),
+		assetString(htlc.Asset),
+		time.Unix(htlc.Expiry, 0).Format(time.RFC3339),
+	)
+	return htlc, nil
+}
+
+func getCollectionInfo(
+	ctx context.Context,
+	cli *client.Client,
+	collectionID ids.ID,
+) (bool, string, error) {
+	exists, metadata, owner, supply, err := cli.Collection(ctx, collectionID)
+	if err != nil {
+		return false, "", err
+	}
+	if !exists {
+		hutils.Outf("{{red}}%s does not exist{{/}}\n", collectionID)
+		hutils.Outf("{{red}}exiting...{{/}}\n")
+		return false, "", nil
+	}
+	hutils.Outf(
+		"{{yellow}}metadata:{{/}} %s {{yellow}}owner:{{/}} %s {{yellow}}supply:{{/}} %d\n",
+		string(metadata),
+		owner,
+		supply,
+	)
+	return true, owner, nil
+}
+
+func getNFTInfo(
+	ctx context.Context,
+	cli *client.Client,
+	collectionID ids.ID,
+	id uint64,
+) (bool, string, error) {
+	exists, owner, metadata, err := cli.NFT(ctx, collectionID, id)
+	if err != nil {
+		return false, "", err
+	}
+	if !exists {
+		hutils.Outf("{{red}}%s/%d does not exist{{/}}\n", collectionID, id)
+		hutils.Outf("{{red}}exiting...{{/}}\n")
+		return false, "", nil
+	}
+	hutils.Outf(
+		"{{yellow}}owner:{{/}} %s {{yellow}}metadata:{{/}} %s\n",
+		owner,
+		string(metadata),
+	)
+	return true, owner, nil
+}
+
+func getAirdropInfo(
+	ctx context.Context,
+	cli *client.Client,
+	airdropID ids.ID,
+) (*controller.AirdropReply, error) {
+	exists, airdrop, err := cli.Airdrop(ctx, airdropID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		hutils.Outf("{{red}}%s does not exist{{/}}\n", airdropID)
+		hutils.Outf("{{red}}exiting...{{/}}\n")
+		return nil, nil
+	}
+	hutils.Outf(
+		"{{yellow}}creator:{{/}} %s {{yellow}}remaining:{{/}} %s %s {{yellow}}root:{{/}} %s {{yellow}}entries:{{/}} %d {{yellow}}expiry:{{/}} %s\n",
+		airdrop.Creator,
+		valueString(cli, airdrop.Asset, airdrop.Remaining),
+		assetString(airdrop.Asset),
+		airdrop.Root,
+		airdrop.Entries,
+		time.Unix(airdrop.Expiry, 0).Format(time.RFC3339),
+	)
+	return airdrop, nil
+}
+
+func getPoolInfo(
+	ctx context.Context,
+	cli *client.Client,
+	poolID ids.ID,
+) (*controller.PoolReply, error) {
+	exists, pool, err := cli.Pool(ctx, poolID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		hutils.Outf("{{red}}%s does not exist{{/}}\n", poolID)
+		hutils.Outf("{{red}}exiting...{{/}}\n")
+		return nil, nil
+	}
+	hutils.Outf(
+		"{{yellow}}reserves:{{/}} %s %s + %s %s {{yellow}}shares:{{/}} %d {{yellow}}fee:{{/}} %d bps\n",
+		valueString(cli, pool.AssetA, pool.ReserveA),
+		assetString(pool.AssetA),
+		valueString(cli, pool.AssetB, pool.ReserveB),
+		assetString(pool.AssetB),
+		pool.Shares,
+		pool.Fee,
+	)
+	return pool, nil
+}
+
+// applySlippage returns the least of [amount] that should be accepted with a
+// slippage tolerance of [slippage] basis points.
+func applySlippage(amount uint64, slippage uint64) uint64 {
+	// We split [amount] to avoid overflowing when multiplying by [slippage].
+	return amount - (amount/actions.BasisPoints*slippage + amount%actions.BasisPoints*slippage/actions.BasisPoints)
+}
+
+func getStreamInfo(
+	ctx context.Context,
+	cli *client.Client,
+	streamID ids.ID,
+) (*controller.StreamReply, error) {
+	exists, stream, err := cli.Stream(ctx, streamID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		hutils.Outf("{{red}}%s does not exist{{/}}\n", streamID)
+		hutils.Outf("{{red}}exiting...{{/}}\n")
+		return nil, nil
+	}
+	hutils.Outf(
+		"{{yellow}}sender:{{/}} %s {{yellow}}recipient:{{/}} %s {{yellow}}deposit:{{/}} %s %s {{yellow}}rate:{{/}} %s/s\n",
+		stream.Sender,
+		stream.Recipient,
+		valueString(cli, stream.Asset, stream.Deposit),
+		assetString(stream.Asset),
+		valueString(cli, stream.Asset, stream.Rate),
+	)
+	hutils.Outf(
+		"{{yellow}}streamed:{{/}} %s {{yellow}}withdrawn:{{/}} %s {{yellow}}withdrawable:{{/}} %s %s\n",
+		valueString(cli, stream.Asset, stream.Streamed),
+		valueString(cli, stream.Asset, stream.Withdrawn),
+		valueString(cli, stream.Asset, stream.Withdrawable),
+		assetString(stream.Asset),
+	)
+	return stream, nil
+}
@@ -35,6 +35,11 @@ type Config struct {
 	MempoolPayerSize    int      `json:"mempoolPayerSize"`
 	MempoolExemptPayers []string `json:"mempoolExemptPayers"`
 
+	// Order Book
+	//
+	// This is denoted as <asset 1>-<asset 2> (use "*" to track all pairs)
+	TrackedPairs []string `json:"trackedPairs"` // which asset ID pairs we care about
+
 	// Misc
 	TestMode    bool          `json:"testMode"` // makes gossip/building manual
 	LogLevel    logging.Level `json:"logLevel"`
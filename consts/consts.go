@@ -9,9 +9,10 @@ import (
 )
 
 const (
-	HRP    = "token"
-	Name   = "tokenvm"
-	Symbol = "TKN"
+	HRP      = "token"
+	Name     = "tokenvm"
+	Symbol   = "TKN"
+	Decimals = 9
 )
 
 var ID ids.ID
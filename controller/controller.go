@@ -6,10 +6,12 @@ import (
 
 	ametrics "github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/hypersdk/builder"
 	"github.com/ava-labs/hypersdk/chain"
+	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/gossiper"
 	"github.com/ava-labs/hypersdk/pebble"
 	"github.com/ava-labs/hypersdk/utils"
@@ -17,9 +19,11 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/rafael-abuawad/samplevm/actions"
+	"github.com/rafael-abuawad/samplevm/auth"
 	"github.com/rafael-abuawad/samplevm/config"
 	"github.com/rafael-abuawad/samplevm/consts"
 	"github.com/rafael-abuawad/samplevm/genesis"
+	"github.com/rafael-abuawad/samplevm/orderbook"
 	"github.com/rafael-abuawad/samplevm/storage"
 	"github.com/rafael-abuawad/samplevm/version"
 )
@@ -34,6 +38,8 @@ type Controller struct {
 	stateManager *StateManager
 	metrics      *metrics
 	metaDB       database.Database
+
+	orderBook *orderbook.OrderBook
 }
 
 func New() *vm.VM {
@@ -115,6 +121,9 @@ func (c *Controller) Initialize(
 		return nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 	}
 
+	// Initialize order book used to track all open orders
+	c.orderBook = orderbook.New(snowCtx.Log, c.config.TrackedPairs)
+
 	// Create handlers
 	apis := map[string]*common.HTTPHandler{}
 	endpoint, err := utils.NewHandler(consts.Name, &Handler{inner.Handler(), c})
@@ -169,19 +178,145 @@ func (c *Controller) Accepted(ctx context.Context, blk *chain.StatelessBlock) er
 			return err
 		}
 		if result.Success {
-			switch tx.Action.(type) {
-			case *actions.CreateAsset:
-				c.metrics.createAsset.Inc()
-			case *actions.MintAsset:
-				c.metrics.mintAsset.Inc()
-			case *actions.Transfer:
-				c.metrics.transfer.Inc()
+			if err := c.accepted(ctx, batch, tx.Action, auth.GetActor(tx.Auth), tx.ID(), result.Output); err != nil {
+				return err
 			}
 		}
 	}
 	return batch.Write()
 }
 
+// accepted updates the metrics and indexes for a successfully executed
+// [action] issued by [actor]. [actionID] is the ID the action was executed
+// with, which is the ID of the transaction unless the action is part of a
+// [actions.Sequence].
+func (c *Controller) accepted(
+	ctx context.Context,
+	batch database.Batch,
+	action chain.Action,
+	actor crypto.PublicKey,
+	actionID ids.ID,
+	output []byte,
+) error {
+	switch action := action.(type) {
+	case *actions.CreateAsset:
+		c.metrics.createAsset.Inc()
+	case *actions.MintAsset:
+		c.metrics.mintAsset.Inc()
+	case *actions.BurnAsset:
+		c.metrics.burnAsset.Inc()
+	case *actions.ModifyAsset:
+		c.metrics.modifyAsset.Inc()
+	case *actions.ExportAsset:
+		c.metrics.exportAsset.Inc()
+	case *actions.ImportAsset:
+		c.metrics.importAsset.Inc()
+	case *actions.CreateOrder:
+		c.metrics.createOrder.Inc()
+		c.orderBook.Add(actionID, actor, action)
+	case *actions.FillOrder:
+		c.metrics.fillOrder.Inc()
+		orderResult, err := actions.UnmarshalOrderResult(output)
+		if err != nil {
+			// This should never happen
+			return err
+		}
+		if orderResult.Remaining == 0 {
+			c.orderBook.Remove(action.Order)
+		} else {
+			c.orderBook.UpdateRemaining(action.Order, orderResult.Remaining)
+		}
+	case *actions.CloseOrder:
+		c.metrics.closeOrder.Inc()
+		c.orderBook.Remove(action.Order)
+	case *actions.Transfer:
+		c.metrics.transfer.Inc()
+	case *actions.TransferWithMemo:
+		c.metrics.transferWithMemo.Inc()
+		if err := storage.StoreMemo(ctx, batch, actionID, action.Memo); err != nil {
+			return err
+		}
+	case *actions.CreateMultisig:
+		c.metrics.createMultisig.Inc()
+	case *actions.RegisterSessionKey:
+		c.metrics.registerSessionKey.Inc()
+	case *actions.Sequence:
+		c.metrics.sequence.Inc()
+		sequenceResult, err := actions.UnmarshalSequenceResult(output)
+		if err != nil {
+			// This should never happen
+			return err
+		}
+		for i := range action.Actions {
+			stepID := actions.SequenceStepID(actionID, i)
+			if err := c.accepted(ctx, batch, action.Step(actionID, i), actor, stepID, sequenceResult.Outputs[i]); err != nil {
+				return err
+			}
+		}
+	case *actions.CancelStream:
+		c.metrics.cancelStream.Inc()
+	case *actions.WithdrawStream:
+		c.metrics.withdrawStream.Inc()
+	case *actions.CreateStream:
+		c.metrics.createStream.Inc()
+	case *actions.Swap:
+		c.metrics.swap.Inc()
+	case *actions.RemoveLiquidity:
+		c.metrics.removeLiquidity.Inc()
+	case *actions.AddLiquidity:
+		c.metrics.addLiquidity.Inc()
+	case *actions.CreatePool:
+		c.metrics.createPool.Inc()
+	case *actions.ReclaimAirdrop:
+		c.metrics.reclaimAirdrop.Inc()
+	case *actions.ClaimAirdrop:
+		c.metrics.claimAirdrop.Inc()
+	case *actions.CreateAirdrop:
+		c.metrics.createAirdrop.Inc()
+	case *actions.BurnNFT:
+		c.metrics.burnNFT.Inc()
+		if err := storage.RemoveCollectionNFT(ctx, batch, action.Collection, action.ID); err != nil {
+			return err
+		}
+	case *actions.TransferNFT:
+		c.metrics.transferNFT.Inc()
+	case *actions.MintNFT:
+		c.metrics.mintNFT.Inc()
+		if err := storage.StoreCollectionNFT(ctx, batch, action.Collection, action.ID); err != nil {
+			return err
+		}
+	case *actions.CreateCollection:
+		c.metrics.createCollection.Inc()
+	case *actions.ClaimFees:
+		c.metrics.claimFees.Inc()
+	case *actions.Clawback:
+		c.metrics.clawback.Inc()
+	case *actions.UnfreezeAccount:
+		c.metrics.unfreezeAccount.Inc()
+	case *actions.FreezeAccount:
+		c.metrics.freezeAccount.Inc()
+	case *actions.ClaimVested:
+		c.metrics.claimVested.Inc()
+	case *actions.CreateVesting:
+		c.metrics.createVesting.Inc()
+	case *actions.RefundHTLC:
+		c.metrics.refundHTLC.Inc()
+	case *actions.ClaimHTLC:
+		c.metrics.claimHTLC.Inc()
+	case *actions.LockHTLC:
+		c.metrics.lockHTLC.Inc()
+	case *actions.TransferFrom:
+		c.metrics.transferFrom.Inc()
+	case *actions.Approve:
+		c.metrics.approve.Inc()
+	case *actions.BatchTransfer:
+		c.metrics.batchTransfer.Inc()
+	case *actions.TransferAssetOwnership:
+		c.metrics.transferAssetOwnership.Inc()
+	}
+	return nil
+}
+
 func (*Controller) Rejected(context.Context, *chain.StatelessBlock) error {
 	return nil
 }
@@ -7,14 +7,35 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/hypersdk/vm"
 
+	"github.com/rafael-abuawad/samplevm/actions"
+	"github.com/rafael-abuawad/samplevm/auth"
 	"github.com/rafael-abuawad/samplevm/genesis"
+	"github.com/rafael-abuawad/samplevm/orderbook"
 	"github.com/rafael-abuawad/samplevm/storage"
 	"github.com/rafael-abuawad/samplevm/utils"
 )
 
+const (
+	// ordersToSend is the maximum number of orders returned by [Handler.Orders].
+	ordersToSend = 128
+
+	// nftsToSend is the maximum number of NFTs returned by [Handler.Nfts].
+	nftsToSend = 1024
+)
+
 var (
-	ErrTxNotFound    = errors.New("tx not found")
-	ErrAssetNotFound = errors.New("asset not found")
+	ErrTxNotFound         = errors.New("tx not found")
+	ErrAssetNotFound      = errors.New("asset not found")
+	ErrHTLCNotFound       = errors.New("htlc not found")
+	ErrVestingNotFound    = errors.New("vesting not found")
+	ErrCollectionNotFound = errors.New("collection not found")
+	ErrNFTNotFound        = errors.New("nft not found")
+	ErrAirdropNotFound    = errors.New("airdrop not found")
+	ErrPoolNotFound       = errors.New("pool not found")
+	ErrWrongPoolAsset     = errors.New("asset not in pool")
+	ErrStreamNotFound     = errors.New("stream not found")
+	ErrMultisigNotFound   = errors.New("multisig not found")
+	ErrSessionKeyNotFound = errors.New("session key not found")
 )
 
 type Handler struct {
@@ -40,6 +61,7 @@ type TxReply struct {
 	Timestamp int64  `json:"timestamp"`
 	Success   bool   `json:"success"`
 	Units     uint64 `json:"units"`
+	Memo      []byte `json:"memo"`
 }
 
 func (h *Handler) Tx(req *http.Request, args *TxArgs, reply *TxReply) error {
@@ -53,9 +75,36 @@ func (h *Handler) Tx(req *http.Request, args *TxArgs, reply *TxReply) error {
 	if !found {
 		return ErrTxNotFound
 	}
+	memo, err := storage.GetMemo(ctx, h.c.metaDB, args.TxID)
+	if err != nil {
+		return err
+	}
 	reply.Timestamp = t
 	reply.Success = success
 	reply.Units = units
+	reply.Memo = memo
+	return nil
+}
+
+type MemoArgs struct {
+	ActionID ids.ID `json:"actionId"`
+}
+
+type MemoReply struct {
+	Memo []byte `json:"memo"`
+}
+
+// Memo returns the memo of an accepted [actions.TransferWithMemo]. The ID of
+// an action in an [actions.Sequence] is its [actions.SequenceStepID].
+func (h *Handler) Memo(req *http.Request, args *MemoArgs, reply *MemoReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Memo")
+	defer span.End()
+
+	memo, err := storage.GetMemo(ctx, h.c.metaDB, args.ActionID)
+	if err != nil {
+		return err
+	}
+	reply.Memo = memo
 	return nil
 }
 
@@ -64,17 +113,30 @@ type AssetArgs struct {
 }
 
 type AssetReply struct {
-	Metadata []byte `json:"metadata"`
-	Supply   uint64 `json:"supply"`
-	Owner    string `json:"owner"`
-	Warp     bool   `json:"warp"`
+	Symbol   []byte `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+	Name     []byte `json:"name"`
+	URI      []byte `json:"uri"`
+	URIHash  ids.ID `json:"uriHash"`
+
+	Metadata  []byte `json:"metadata"`
+	Supply    uint64 `json:"supply"`
+	MaxSupply uint64 `json:"maxSupply"`
+	Owner     string `json:"owner"`
+	Warp      bool   `json:"warp"`
+	Regulated bool   `json:"regulated"`
+
+	// Fee is the transfer fee in basis points that is accrued for
+	// [FeeRecipient].
+	Fee          uint64 `json:"fee"`
+	FeeRecipient string `json:"feeRecipient"`
 }
 
 func (h *Handler) Asset(req *http.Request, args *AssetArgs, reply *AssetReply) error {
 	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Asset")
 	defer span.End()
 
-	exists, metadata, supply, owner, warp, err := storage.GetAssetFromState(
+	exists, asset, err := storage.GetAssetFromState(
 		ctx,
 		h.c.inner.ReadState,
 		args.Asset,
@@ -85,10 +147,19 @@ func (h *Handler) Asset(req *http.Request, args *AssetArgs, reply *AssetReply) e
 	if !exists {
 		return ErrAssetNotFound
 	}
-	reply.Metadata = metadata
-	reply.Supply = supply
-	reply.Owner = utils.Address(owner)
-	reply.Warp = warp
+	reply.Symbol = asset.Symbol
+	reply.Decimals = asset.Decimals
+	reply.Name = asset.Name
+	reply.URI = asset.URI
+	reply.URIHash = asset.URIHash
+	reply.Metadata = asset.Metadata
+	reply.Supply = asset.Supply
+	reply.MaxSupply = asset.MaxSupply
+	reply.Owner = utils.Address(asset.Owner)
+	reply.Warp = asset.Warp
+	reply.Regulated = asset.Regulated
+	reply.Fee = asset.Fee
+	reply.FeeRecipient = utils.Address(asset.FeeRecipient)
 	return err
 }
 
@@ -116,3 +187,582 @@ func (h *Handler) Balance(req *http.Request, args *BalanceArgs, reply *BalanceRe
 	reply.Amount = balance
 	return err
 }
+
+type LoanArgs struct {
+	Destination ids.ID `json:"destination"`
+	Asset       ids.ID `json:"asset"`
+}
+
+type LoanReply struct {
+	Amount uint64 `json:"amount"`
+}
+
+func (h *Handler) Loan(req *http.Request, args *LoanArgs, reply *LoanReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Loan")
+	defer span.End()
+
+	amount, err := storage.GetLoanFromState(ctx, h.c.inner.ReadState, args.Asset, args.Destination)
+	if err != nil {
+		return err
+	}
+	reply.Amount = amount
+	return nil
+}
+
+type AllowanceArgs struct {
+	Owner   string `json:"owner"`
+	Spender string `json:"spender"`
+	Asset   ids.ID `json:"asset"`
+}
+
+type AllowanceReply struct {
+	Amount uint64 `json:"amount"`
+}
+
+func (h *Handler) Allowance(req *http.Request, args *AllowanceArgs, reply *AllowanceReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Allowance")
+	defer span.End()
+
+	owner, err := utils.ParseAddress(args.Owner)
+	if err != nil {
+		return err
+	}
+	spender, err := utils.ParseAddress(args.Spender)
+	if err != nil {
+		return err
+	}
+	amount, err := storage.GetAllowanceFromState(ctx, h.c.inner.ReadState, owner, spender, args.Asset)
+	if err != nil {
+		return err
+	}
+	reply.Amount = amount
+	return nil
+}
+
+type FeesArgs struct {
+	Asset ids.ID `json:"asset"`
+}
+
+type FeesReply struct {
+	Amount uint64 `json:"amount"`
+}
+
+func (h *Handler) Fees(req *http.Request, args *FeesArgs, reply *FeesReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Fees")
+	defer span.End()
+
+	amount, err := storage.GetFeesFromState(ctx, h.c.inner.ReadState, args.Asset)
+	if err != nil {
+		return err
+	}
+	reply.Amount = amount
+	return nil
+}
+
+type FrozenArgs struct {
+	Asset   ids.ID `json:"asset"`
+	Address string `json:"address"`
+}
+
+type FrozenReply struct {
+	Frozen bool `json:"frozen"`
+}
+
+func (h *Handler) Frozen(req *http.Request, args *FrozenArgs, reply *FrozenReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Frozen")
+	defer span.End()
+
+	addr, err := utils.ParseAddress(args.Address)
+	if err != nil {
+		return err
+	}
+	frozen, err := storage.GetFrozenFromState(ctx, h.c.inner.ReadState, args.Asset, addr)
+	if err != nil {
+		return err
+	}
+	reply.Frozen = frozen
+	return nil
+}
+
+type HTLCArgs struct {
+	HTLC ids.ID `json:"htlc"`
+}
+
+type HTLCReply struct {
+	Sender string `json:"sender"`
+	To     string `json:"to"`
+	Asset  ids.ID `json:"asset"`
+	Value  uint64 `json:"value"`
+	Hash   ids.ID `json:"hash"`
+	Expiry int64  `json:"expiry"`
+}
+
+// Htlc is not named HTLC because only the first letter of the requested
+// method ("htlc") is capitalized when it is resolved.
+func (h *Handler) Htlc(req *http.Request, args *HTLCArgs, reply *HTLCReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Htlc")
+	defer span.End()
+
+	exists, sender, to, asset, value, hash, expiry, err := storage.GetHTLCFromState(
+		ctx,
+		h.c.inner.ReadState,
+		args.HTLC,
+	)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrHTLCNotFound
+	}
+	reply.Sender = utils.Address(sender)
+	reply.To = utils.Address(to)
+	reply.Asset = asset
+	reply.Value = value
+	reply.Hash = hash
+	reply.Expiry = expiry
+	return nil
+}
+
+type VestingArgs struct {
+	Vesting ids.ID `json:"vesting"`
+}
+
+type VestingReply struct {
+	Beneficiary string `json:"beneficiary"`
+	Asset       ids.ID `json:"asset"`
+	Total       uint64 `json:"total"`
+	Claimed     uint64 `json:"claimed"`
+	Start       int64  `json:"start"`
+	Cliff       int64  `json:"cliff"`
+	Duration    int64  `json:"duration"`
+
+	// Vested and Unvested are computed as of the timestamp of the last
+	// accepted block.
+	Timestamp int64  `json:"timestamp"`
+	Vested    uint64 `json:"vested"`
+	Unvested  uint64 `json:"unvested"`
+}
+
+func (h *Handler) Vesting(req *http.Request, args *VestingArgs, reply *VestingReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Vesting")
+	defer span.End()
+
+	exists, beneficiary, asset, total, claimed, start, cliff, duration, err := storage.GetVestingFromState(
+		ctx,
+		h.c.inner.ReadState,
+		args.Vesting,
+	)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrVestingNotFound
+	}
+	t := h.c.inner.LastAcceptedBlock().Tmstmp
+	vested := actions.VestedAmount(total, start, cliff, duration, t)
+	reply.Beneficiary = utils.Address(beneficiary)
+	reply.Asset = asset
+	reply.Total = total
+	reply.Claimed = claimed
+	reply.Start = start
+	reply.Cliff = cliff
+	reply.Duration = duration
+	reply.Timestamp = t
+	reply.Vested = vested
+	reply.Unvested = total - vested
+	return nil
+}
+
+type StreamArgs struct {
+	Stream ids.ID `json:"stream"`
+}
+
+type StreamReply struct {
+	Sender    string `json:"sender"`
+	Recipient string `json:"recipient"`
+	Asset     ids.ID `json:"asset"`
+	Deposit   uint64 `json:"deposit"`
+	Rate      uint64 `json:"rate"`
+	Start     int64  `json:"start"`
+	Withdrawn uint64 `json:"withdrawn"`
+
+	// Streamed and Withdrawable are computed as of the timestamp of the last
+	// accepted block.
+	Timestamp    int64  `json:"timestamp"`
+	Streamed     uint64 `json:"streamed"`
+	Withdrawable uint64 `json:"withdrawable"`
+}
+
+func (h *Handler) Stream(req *http.Request, args *StreamArgs, reply *StreamReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Stream")
+	defer span.End()
+
+	exists, sender, recipient, asset, deposit, rate, start, withdrawn, err := storage.GetStreamFromState(
+		ctx,
+		h.c.inner.ReadState,
+		args.Stream,
+	)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrStreamNotFound
+	}
+	t := h.c.inner.LastAcceptedBlock().Tmstmp
+	streamed := actions.StreamedAmount(deposit, rate, start, t)
+	reply.Sender = utils.Address(sender)
+	reply.Recipient = utils.Address(recipient)
+	reply.Asset = asset
+	reply.Deposit = deposit
+	reply.Rate = rate
+	reply.Start = start
+	reply.Withdrawn = withdrawn
+	reply.Timestamp = t
+	reply.Streamed = streamed
+	reply.Withdrawable = streamed - withdrawn
+	return nil
+}
+
+type AirdropArgs struct {
+	Airdrop ids.ID `json:"airdrop"`
+}
+
+type AirdropReply struct {
+	Creator   string `json:"creator"`
+	Asset     ids.ID `json:"asset"`
+	Root      ids.ID `json:"root"`
+	Entries   uint64 `json:"entries"`
+	Remaining uint64 `json:"remaining"`
+	Expiry    int64  `json:"expiry"`
+}
+
+func (h *Handler) Airdrop(req *http.Request, args *AirdropArgs, reply *AirdropReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Airdrop")
+	defer span.End()
+
+	exists, creator, asset, root, entries, remaining, expiry, err := storage.GetAirdropFromState(
+		ctx,
+		h.c.inner.ReadState,
+		args.Airdrop,
+	)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrAirdropNotFound
+	}
+	reply.Creator = utils.Address(creator)
+	reply.Asset = asset
+	reply.Root = root
+	reply.Entries = entries
+	reply.Remaining = remaining
+	reply.Expiry = expiry
+	return nil
+}
+
+type AirdropClaimedArgs struct {
+	Airdrop ids.ID `json:"airdrop"`
+	Index   uint64 `json:"index"`
+}
+
+type AirdropClaimedReply struct {
+	Claimed bool `json:"claimed"`
+}
+
+func (h *Handler) AirdropClaimed(
+	req *http.Request,
+	args *AirdropClaimedArgs,
+	reply *AirdropClaimedReply,
+) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.AirdropClaimed")
+	defer span.End()
+
+	claimed, err := storage.GetAirdropClaimedFromState(ctx, h.c.inner.ReadState, args.Airdrop, args.Index)
+	if err != nil {
+		return err
+	}
+	reply.Claimed = claimed
+	return nil
+}
+
+type CollectionArgs struct {
+	Collection ids.ID `json:"collection"`
+}
+
+type CollectionReply struct {
+	Metadata []byte `json:"metadata"`
+	Owner    string `json:"owner"`
+	Supply   uint64 `json:"supply"`
+}
+
+func (h *Handler) Collection(req *http.Request, args *CollectionArgs, reply *CollectionReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Collection")
+	defer span.End()
+
+	exists, metadata, owner, supply, err := storage.GetCollectionFromState(
+		ctx,
+		h.c.inner.ReadState,
+		args.Collection,
+	)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrCollectionNotFound
+	}
+	reply.Metadata = metadata
+	reply.Owner = utils.Address(owner)
+	reply.Supply = supply
+	return nil
+}
+
+type NFTArgs struct {
+	Collection ids.ID `json:"collection"`
+	ID         uint64 `json:"id"`
+}
+
+type NFTReply struct {
+	Owner    string `json:"owner"`
+	Metadata []byte `json:"metadata"`
+}
+
+// Nft and Nfts are not named NFT and NFTs because only the first letter of
+// the requested method ("nft" and "nfts") is capitalized when it is resolved.
+func (h *Handler) Nft(req *http.Request, args *NFTArgs, reply *NFTReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Nft")
+	defer span.End()
+
+	exists, owner, metadata, err := storage.GetNFTFromState(
+		ctx,
+		h.c.inner.ReadState,
+		args.Collection,
+		args.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNFTNotFound
+	}
+	reply.Owner = utils.Address(owner)
+	reply.Metadata = metadata
+	return nil
+}
+
+type NFTsArgs struct {
+	Collection ids.ID `json:"collection"`
+
+	// Start is the lowest NFT ID to return. To fetch the next page, set
+	// [Start] to one more than the last ID returned.
+	Start uint64 `json:"start"`
+}
+
+type NFTsReply struct {
+	IDs []uint64 `json:"ids"`
+}
+
+func (h *Handler) Nfts(req *http.Request, args *NFTsArgs, reply *NFTsReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Nfts")
+	defer span.End()
+
+	nfts, err := storage.GetCollectionNFTs(ctx, h.c.metaDB, args.Collection, args.Start, nftsToSend)
+	if err != nil {
+		return err
+	}
+	reply.IDs = nfts
+	return nil
+}
+
+type PoolArgs struct {
+	Pool ids.ID `json:"pool"`
+}
+
+type PoolReply struct {
+	AssetA   ids.ID `json:"assetA"`
+	AssetB   ids.ID `json:"assetB"`
+	ReserveA uint64 `json:"reserveA"`
+	ReserveB uint64 `json:"reserveB"`
+	Fee      uint64 `json:"fee"`
+	Shares   uint64 `json:"shares"`
+}
+
+func (h *Handler) Pool(req *http.Request, args *PoolArgs, reply *PoolReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Pool")
+	defer span.End()
+
+	exists, assetA, assetB, reserveA, reserveB, fee, err := storage.GetPoolFromState(
+		ctx,
+		h.c.inner.ReadState,
+		args.Pool,
+	)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrPoolNotFound
+	}
+	exists, shareAsset, err := storage.GetAssetFromState(ctx, h.c.inner.ReadState, args.Pool)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrAssetNotFound
+	}
+	reply.AssetA = assetA
+	reply.AssetB = assetB
+	reply.ReserveA = reserveA
+	reply.ReserveB = reserveB
+	reply.Fee = fee
+	reply.Shares = shareAsset.Supply
+	return nil
+}
+
+type QuoteArgs struct {
+	Pool  ids.ID `json:"pool"`
+	In    ids.ID `json:"in"`
+	Value uint64 `json:"value"`
+}
+
+type QuoteReply struct {
+	Out ids.ID `json:"out"`
+
+	// AmountOut is the amount of [Out] that would be received (after any
+	// transfer fee) by swapping [Value] of [In] at the current reserves.
+	AmountOut uint64 `json:"amountOut"`
+	Fee       uint64 `json:"fee"`
+}
+
+func (h *Handler) Quote(req *http.Request, args *QuoteArgs, reply *QuoteReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Quote")
+	defer span.End()
+
+	exists, assetA, assetB, reserveA, reserveB, fee, err := storage.GetPoolFromState(
+		ctx,
+		h.c.inner.ReadState,
+		args.Pool,
+	)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrPoolNotFound
+	}
+	var (
+		out                   ids.ID
+		reserveIn, reserveOut uint64
+	)
+	switch args.In {
+	case assetA:
+		out, reserveIn, reserveOut = assetB, reserveA, reserveB
+	case assetB:
+		out, reserveIn, reserveOut = assetA, reserveB, reserveA
+	default:
+		return ErrWrongPoolAsset
+	}
+	exists, outAsset, err := storage.GetAssetFromState(ctx, h.c.inner.ReadState, out)
+	if err != nil {
+		return err
+	}
+	var transferFee uint64
+	if exists {
+		transferFee = outAsset.Fee
+	}
+	amountOut := actions.SwapOutput(reserveIn, reserveOut, args.Value, fee)
+	withheld := actions.TransferFee(amountOut, transferFee)
+	reply.Out = out
+	reply.AmountOut = amountOut - withheld
+	reply.Fee = withheld
+	return nil
+}
+
+type MultisigArgs struct {
+	Address string `json:"address"`
+}
+
+type MultisigReply struct {
+	Threshold uint8    `json:"threshold"`
+	Signers   []string `json:"signers"`
+}
+
+func (h *Handler) Multisig(req *http.Request, args *MultisigArgs, reply *MultisigReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Multisig")
+	defer span.End()
+
+	addr, err := utils.ParseAddress(args.Address)
+	if err != nil {
+		return err
+	}
+	exists, threshold, signers, err := storage.GetMultisigFromState(ctx, h.c.inner.ReadState, addr)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrMultisigNotFound
+	}
+	reply.Threshold = threshold
+	reply.Signers = make([]string, len(signers))
+	for i, signer := range signers {
+		reply.Signers[i] = utils.Address(signer)
+	}
+	return nil
+}
+
+type SessionKeyArgs struct {
+	Address    string `json:"address"`
+	SessionKey string `json:"sessionKey"`
+}
+
+type SessionKeyReply struct {
+	Expiry  int64          `json:"expiry"`
+	Actions []uint8        `json:"actions"`
+	Limits  []*auth.Amount `json:"limits"`
+}
+
+func (h *Handler) SessionKey(req *http.Request, args *SessionKeyArgs, reply *SessionKeyReply) error {
+	ctx, span := h.c.inner.Tracer().Start(req.Context(), "Handler.SessionKey")
+	defer span.End()
+
+	addr, err := utils.ParseAddress(args.Address)
+	if err != nil {
+		return err
+	}
+	sessionKey, err := utils.ParseAddress(args.SessionKey)
+	if err != nil {
+		return err
+	}
+	exists, expiry, allowed, assets, remaining, err := storage.GetSessionKeyFromState(
+		ctx,
+		h.c.inner.ReadState,
+		addr,
+		sessionKey,
+	)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrSessionKeyNotFound
+	}
+	reply.Expiry = expiry
+	reply.Actions = allowed
+	reply.Limits = make([]*auth.Amount, len(assets))
+	for i, asset := range assets {
+		reply.Limits[i] = &auth.Amount{Asset: asset, Value: remaining[i]}
+	}
+	return nil
+}
+
+type OrdersArgs struct {
+	Pair string `json:"pair"`
+}
+
+type OrdersReply struct {
+	Orders []*orderbook.Order `json:"orders"`
+}
+
+func (h *Handler) Orders(req *http.Request, args *OrdersArgs, reply *OrdersReply) error {
+	_, span := h.c.inner.Tracer().Start(req.Context(), "Handler.Orders")
+	defer span.End()
+
+	reply.Orders = h.c.orderBook.Orders(args.Pair, ordersToSend)
+	return nil
+}
@@ -8,13 +8,47 @@ import (
 )
 
 type metrics struct {
-	createAsset prometheus.Counter
-	mintAsset   prometheus.Counter
-	burnAsset   prometheus.Counter
-	modifyAsset prometheus.Counter
-	transfer    prometheus.Counter
-	importAsset prometheus.Counter
-	exportAsset prometheus.Counter
+	createAsset            prometheus.Counter
+	mintAsset              prometheus.Counter
+	burnAsset              prometheus.Counter
+	modifyAsset            prometheus.Counter
+	transfer               prometheus.Counter
+	importAsset            prometheus.Counter
+	exportAsset            prometheus.Counter
+	createOrder            prometheus.Counter
+	fillOrder              prometheus.Counter
+	closeOrder             prometheus.Counter
+	transferAssetOwnership prometheus.Counter
+	batchTransfer          prometheus.Counter
+	approve                prometheus.Counter
+	transferFrom           prometheus.Counter
+	lockHTLC               prometheus.Counter
+	claimHTLC              prometheus.Counter
+	refundHTLC             prometheus.Counter
+	createVesting          prometheus.Counter
+	claimVested            prometheus.Counter
+	freezeAccount          prometheus.Counter
+	unfreezeAccount        prometheus.Counter
+	clawback               prometheus.Counter
+	claimFees              prometheus.Counter
+	createCollection       prometheus.Counter
+	mintNFT                prometheus.Counter
+	transferNFT            prometheus.Counter
+	burnNFT                prometheus.Counter
+	createAirdrop          prometheus.Counter
+	claimAirdrop           prometheus.Counter
+	reclaimAirdrop         prometheus.Counter
+	createPool             prometheus.Counter
+	addLiquidity           prometheus.Counter
+	removeLiquidity        prometheus.Counter
+	swap                   prometheus.Counter
+	createStream           prometheus.Counter
+	withdrawStream         prometheus.Counter
+	cancelStream           prometheus.Counter
+	sequence               prometheus.Counter
+	createMultisig         prometheus.Counter
+	registerSessionKey     prometheus.Counter
+	transferWithMemo       prometheus.Counter
 }
 
 func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
@@ -54,6 +88,176 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 			Name:      "export_asset",
 			Help:      "number of export asset actions",
 		}),
+		createOrder: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_order",
+			Help:      "number of create order actions",
+		}),
+		fillOrder: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "fill_order",
+			Help:      "number of fill order actions",
+		}),
+		closeOrder: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "close_order",
+			Help:      "number of close order actions",
+		}),
+		transferAssetOwnership: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "transfer_asset_ownership",
+			Help:      "number of transfer asset ownership actions",
+		}),
+		batchTransfer: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "batch_transfer",
+			Help:      "number of batch transfer actions",
+		}),
+		approve: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "approve",
+			Help:      "number of approve actions",
+		}),
+		transferFrom: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "transfer_from",
+			Help:      "number of transfer from actions",
+		}),
+		lockHTLC: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "lock_htlc",
+			Help:      "number of lock htlc actions",
+		}),
+		claimHTLC: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "claim_htlc",
+			Help:      "number of claim htlc actions",
+		}),
+		refundHTLC: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "refund_htlc",
+			Help:      "number of refund htlc actions",
+		}),
+		createVesting: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_vesting",
+			Help:      "number of create vesting actions",
+		}),
+		claimVested: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "claim_vested",
+			Help:      "number of claim vested actions",
+		}),
+		freezeAccount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "freeze_account",
+			Help:      "number of freeze account actions",
+		}),
+		unfreezeAccount: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "unfreeze_account",
+			Help:      "number of unfreeze account actions",
+		}),
+		clawback: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "clawback",
+			Help:      "number of clawback actions",
+		}),
+		claimFees: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "claim_fees",
+			Help:      "number of claim fees actions",
+		}),
+		createCollection: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_collection",
+			Help:      "number of create collection actions",
+		}),
+		mintNFT: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "mint_nft",
+			Help:      "number of mint nft actions",
+		}),
+		transferNFT: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "transfer_nft",
+			Help:      "number of transfer nft actions",
+		}),
+		burnNFT: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "burn_nft",
+			Help:      "number of burn nft actions",
+		}),
+		createAirdrop: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_airdrop",
+			Help:      "number of create airdrop actions",
+		}),
+		claimAirdrop: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "claim_airdrop",
+			Help:      "number of claim airdrop actions",
+		}),
+		reclaimAirdrop: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "reclaim_airdrop",
+			Help:      "number of reclaim airdrop actions",
+		}),
+		createPool: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_pool",
+			Help:      "number of create pool actions",
+		}),
+		addLiquidity: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "add_liquidity",
+			Help:      "number of add liquidity actions",
+		}),
+		removeLiquidity: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "remove_liquidity",
+			Help:      "number of remove liquidity actions",
+		}),
+		swap: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "swap",
+			Help:      "number of swap actions",
+		}),
+		createStream: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_stream",
+			Help:      "number of create stream actions",
+		}),
+		withdrawStream: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "withdraw_stream",
+			Help:      "number of withdraw stream actions",
+		}),
+		cancelStream: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "cancel_stream",
+			Help:      "number of cancel stream actions",
+		}),
+		sequence: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "sequence",
+			Help:      "number of sequence actions",
+		}),
+		createMultisig: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "create_multisig",
+			Help:      "number of create multisig actions",
+		}),
+		registerSessionKey: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "register_session_key",
+			Help:      "number of register session key actions",
+		}),
+		transferWithMemo: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "actions",
+			Name:      "transfer_with_memo",
+			Help:      "number of transfer with memo actions",
+		}),
 	}
 	r := prometheus.NewRegistry()
 	errs := wrappers.Errs{}
@@ -65,6 +269,40 @@ func newMetrics(gatherer ametrics.MultiGatherer) (*metrics, error) {
 		r.Register(m.transfer),
 		r.Register(m.importAsset),
 		r.Register(m.exportAsset),
+		r.Register(m.createOrder),
+		r.Register(m.fillOrder),
+		r.Register(m.closeOrder),
+		r.Register(m.transferAssetOwnership),
+		r.Register(m.batchTransfer),
+		r.Register(m.approve),
+		r.Register(m.transferFrom),
+		r.Register(m.lockHTLC),
+		r.Register(m.claimHTLC),
+		r.Register(m.refundHTLC),
+		r.Register(m.createVesting),
+		r.Register(m.claimVested),
+		r.Register(m.freezeAccount),
+		r.Register(m.unfreezeAccount),
+		r.Register(m.clawback),
+		r.Register(m.claimFees),
+		r.Register(m.createCollection),
+		r.Register(m.mintNFT),
+		r.Register(m.transferNFT),
+		r.Register(m.burnNFT),
+		r.Register(m.createAirdrop),
+		r.Register(m.claimAirdrop),
+		r.Register(m.reclaimAirdrop),
+		r.Register(m.createPool),
+		r.Register(m.addLiquidity),
+		r.Register(m.removeLiquidity),
+		r.Register(m.swap),
+		r.Register(m.createStream),
+		r.Register(m.withdrawStream),
+		r.Register(m.cancelStream),
+		r.Register(m.sequence),
+		r.Register(m.createMultisig),
+		r.Register(m.registerSessionKey),
+		r.Register(m.transferWithMemo),
 		gatherer.Register(consts.Name, r),
 	)
 	return m, errs.Err
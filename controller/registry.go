@@ -22,9 +22,52 @@ func init() {
 		consts.ActionRegistry.Register(&actions.Transfer{}, actions.UnmarshalTransfer, false),
 		consts.ActionRegistry.Register(&actions.CreateAsset{}, actions.UnmarshalCreateAsset, false),
 		consts.ActionRegistry.Register(&actions.MintAsset{}, actions.UnmarshalMintAsset, false),
+		consts.ActionRegistry.Register(&actions.BurnAsset{}, actions.UnmarshalBurnAsset, false),
+		consts.ActionRegistry.Register(&actions.ModifyAsset{}, actions.UnmarshalModifyAsset, false),
+		consts.ActionRegistry.Register(&actions.ExportAsset{}, actions.UnmarshalExportAsset, false),
+		consts.ActionRegistry.Register(&actions.ImportAsset{}, actions.UnmarshalImportAsset, true),
+		consts.ActionRegistry.Register(&actions.CreateOrder{}, actions.UnmarshalCreateOrder, false),
+		consts.ActionRegistry.Register(&actions.FillOrder{}, actions.UnmarshalFillOrder, false),
+		consts.ActionRegistry.Register(&actions.CloseOrder{}, actions.UnmarshalCloseOrder, false),
+		consts.ActionRegistry.Register(&actions.TransferAssetOwnership{}, actions.UnmarshalTransferAssetOwnership, false),
+		consts.ActionRegistry.Register(&actions.BatchTransfer{}, actions.UnmarshalBatchTransfer, false),
+		consts.ActionRegistry.Register(&actions.Approve{}, actions.UnmarshalApprove, false),
+		consts.ActionRegistry.Register(&actions.TransferFrom{}, actions.UnmarshalTransferFrom, false),
+		consts.ActionRegistry.Register(&actions.LockHTLC{}, actions.UnmarshalLockHTLC, false),
+		consts.ActionRegistry.Register(&actions.ClaimHTLC{}, actions.UnmarshalClaimHTLC, false),
+		consts.ActionRegistry.Register(&actions.RefundHTLC{}, actions.UnmarshalRefundHTLC, false),
+		consts.ActionRegistry.Register(&actions.CreateVesting{}, actions.UnmarshalCreateVesting, false),
+		consts.ActionRegistry.Register(&actions.ClaimVested{}, actions.UnmarshalClaimVested, false),
+		consts.ActionRegistry.Register(&actions.FreezeAccount{}, actions.UnmarshalFreezeAccount, false),
+		consts.ActionRegistry.Register(&actions.UnfreezeAccount{}, actions.UnmarshalUnfreezeAccount, false),
+		consts.ActionRegistry.Register(&actions.Clawback{}, actions.UnmarshalClawback, false),
+		consts.ActionRegistry.Register(&actions.ClaimFees{}, actions.UnmarshalClaimFees, false),
+		consts.ActionRegistry.Register(&actions.CreateCollection{}, actions.UnmarshalCreateCollection, false),
+		consts.ActionRegistry.Register(&actions.MintNFT{}, actions.UnmarshalMintNFT, false),
+		consts.ActionRegistry.Register(&actions.TransferNFT{}, actions.UnmarshalTransferNFT, false),
+		consts.ActionRegistry.Register(&actions.BurnNFT{}, actions.UnmarshalBurnNFT, false),
+		consts.ActionRegistry.Register(&actions.CreateAirdrop{}, actions.UnmarshalCreateAirdrop, false),
+		consts.ActionRegistry.Register(&actions.ClaimAirdrop{}, actions.UnmarshalClaimAirdrop, false),
+		consts.ActionRegistry.Register(&actions.ReclaimAirdrop{}, actions.UnmarshalReclaimAirdrop, false),
+		consts.ActionRegistry.Register(&actions.CreatePool{}, actions.UnmarshalCreatePool, false),
+		consts.ActionRegistry.Register(&actions.AddLiquidity{}, actions.UnmarshalAddLiquidity, false),
+		consts.ActionRegistry.Register(&actions.RemoveLiquidity{}, actions.UnmarshalRemoveLiquidity, false),
+		consts.ActionRegistry.Register(&actions.Swap{}, actions.UnmarshalSwap, false),
+		consts.ActionRegistry.Register(&actions.CreateStream{}, actions.UnmarshalCreateStream, false),
+		consts.ActionRegistry.Register(&actions.WithdrawStream{}, actions.UnmarshalWithdrawStream, false),
+		consts.ActionRegistry.Register(&actions.CancelStream{}, actions.UnmarshalCancelStream, false),
+		consts.ActionRegistry.Register(&actions.Sequence{}, actions.UnmarshalSequence, false),
+		consts.ActionRegistry.Register(&actions.CreateMultisig{}, actions.UnmarshalCreateMultisig, false),
+		consts.ActionRegistry.Register(&actions.RegisterSessionKey{}, actions.UnmarshalRegisterSessionKey, false),
+		consts.ActionRegistry.Register(&actions.TransferWithMemo{}, actions.UnmarshalTransferWithMemo, false),
 
 		// when registering new auth, ALWAYS make sure to append at the end.
 		consts.AuthRegistry.Register(&auth.ED25519{}, auth.UnmarshalED25519, false),
+		consts.AuthRegistry.Register(&auth.SECP256K1{}, auth.UnmarshalSECP256K1, false),
+		consts.AuthRegistry.Register(&auth.Multisig{}, auth.UnmarshalMultisig, false),
+		consts.AuthRegistry.Register(&auth.SessionKey{}, auth.UnmarshalSessionKey, false),
+		consts.AuthRegistry.Register(&auth.Sponsored{}, auth.UnmarshalSponsored, false),
+		consts.AuthRegistry.Register(&auth.P256{}, auth.UnmarshalP256, false),
 	)
 	if errs.Errored() {
 		panic(errs.Err)
@@ -10,7 +10,6 @@ import (
 	smath "github.com/ava-labs/avalanchego/utils/math"
 
 	"github.com/ava-labs/hypersdk/chain"
-	"github.com/ava-labs/hypersdk/crypto"
 	"github.com/ava-labs/hypersdk/vm"
 	"github.com/rafael-abuawad/samplevm/consts"
 	"github.com/rafael-abuawad/samplevm/storage"
@@ -120,13 +119,11 @@ func (g *Genesis) Load(ctx context.Context, tracer trace.Tracer, db chain.Databa
 			return fmt.Errorf("%w: addr=%s, bal=%d", err, alloc.Address, alloc.Balance)
 		}
 	}
-	return storage.SetAsset(
-		ctx,
-		db,
-		ids.Empty,
-		[]byte(consts.Symbol),
-		supply,
-		crypto.EmptyPublicKey,
-		false,
-	)
+	return storage.SetAsset(ctx, db, ids.Empty, &storage.Asset{
+		Symbol:   []byte(consts.Symbol),
+		Decimals: consts.Decimals,
+		Name:     []byte(consts.Name),
+		Metadata: []byte(consts.Symbol),
+		Supply:   supply,
+	})
 }
@@ -0,0 +1,153 @@
+package orderbook
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/hypersdk/crypto"
+	"go.uber.org/zap"
+
+	"github.com/rafael-abuawad/samplevm/actions"
+	"github.com/rafael-abuawad/samplevm/utils"
+)
+
+const allPairs = "*"
+
+type Order struct {
+	ID        ids.ID `json:"id"`
+	Owner     string `json:"owner"` // we always send address over RPC
+	InTick    uint64 `json:"inTick"`
+	OutTick   uint64 `json:"outTick"`
+	Remaining uint64 `json:"remaining"`
+}
+
+// rate is the amount of [In] required to receive a single unit of [Out]. A
+// lower rate is better for the counterparty.
+func (o *Order) rate() float64 {
+	return float64(o.InTick) / float64(o.OutTick)
+}
+
+// OrderBook is an in-memory index of all open orders for the configured
+// pairs. It is populated from accepted blocks and only used to serve RPC
+// queries (the source of truth for all orders is always state).
+type OrderBook struct {
+	log logging.Logger
+
+	orders      map[string]map[ids.ID]*Order
+	orderToPair map[ids.ID]string // needed to delete from [CloseOrder] actions
+	l           sync.RWMutex
+
+	trackAll bool
+}
+
+func New(log logging.Logger, trackedPairs []string) *OrderBook {
+	m := map[string]map[ids.ID]*Order{}
+	trackAll := false
+	if len(trackedPairs) == 1 && trackedPairs[0] == allPairs {
+		trackAll = true
+		log.Info("tracking all order books")
+	} else {
+		for _, pair := range trackedPairs {
+			m[pair] = map[ids.ID]*Order{}
+			log.Info("tracking order book", zap.String("pair", pair))
+		}
+	}
+	return &OrderBook{
+		log:         log,
+		orders:      m,
+		orderToPair: map[ids.ID]string{},
+		trackAll:    trackAll,
+	}
+}
+
+func (o *OrderBook) Add(txID ids.ID, actor crypto.PublicKey, action *actions.CreateOrder) {
+	pair := actions.PairID(action.In, action.Out)
+	order := &Order{
+		ID:        txID,
+		Owner:     utils.Address(actor),
+		InTick:    action.InTick,
+		OutTick:   action.OutTick,
+		Remaining: action.Supply,
+	}
+
+	o.l.Lock()
+	defer o.l.Unlock()
+	book, ok := o.orders[pair]
+	switch {
+	case !ok && !o.trackAll:
+		return
+	case !ok && o.trackAll:
+		o.log.Info("tracking order book", zap.String("pair", pair))
+		book = map[ids.ID]*Order{}
+		o.orders[pair] = book
+	}
+	book[order.ID] = order
+	o.orderToPair[order.ID] = pair
+}
+
+func (o *OrderBook) Remove(id ids.ID) {
+	o.l.Lock()
+	defer o.l.Unlock()
+	pair, ok := o.orderToPair[id]
+	if !ok {
+		return
+	}
+	delete(o.orderToPair, id)
+	book, ok := o.orders[pair]
+	if !ok {
+		// This should never happen
+		return
+	}
+	delete(book, id)
+}
+
+func (o *OrderBook) UpdateRemaining(id ids.ID, remaining uint64) {
+	o.l.Lock()
+	defer o.l.Unlock()
+	pair, ok := o.orderToPair[id]
+	if !ok {
+		return
+	}
+	book, ok := o.orders[pair]
+	if !ok {
+		// This should never happen
+		return
+	}
+	order, ok := book[id]
+	if !ok {
+		// This should never happen
+		return
+	}
+	order.Remaining = remaining
+}
+
+// Orders returns up to [limit] open orders for [pair], sorted from the best
+// rate to the worst rate.
+func (o *OrderBook) Orders(pair string, limit int) []*Order {
+	o.l.RLock()
+	defer o.l.RUnlock()
+	book, ok := o.orders[pair]
+	if !ok {
+		return nil
+	}
+	orders := make([]*Order, 0, len(book))
+	for _, order := range book {
+		// We copy each order so that callers can't observe updates to
+		// [Remaining] while serializing the response.
+		c := *order
+		orders = append(orders, &c)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		ri, rj := orders[i].rate(), orders[j].rate()
+		if ri == rj {
+			return orders[i].ID.String() < orders[j].ID.String()
+		}
+		return ri < rj
+	})
+	if limit < len(orders) {
+		orders = orders[:limit]
+	}
+	return orders
+}
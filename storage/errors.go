@@ -2,4 +2,7 @@ package storage
 
 import "errors"
 
-var ErrInvalidBalance = errors.New("invalid balance")
+var (
+	ErrInvalidBalance        = errors.New("invalid balance")
+	ErrInsufficientAllowance = errors.New("insufficient allowance")
+)
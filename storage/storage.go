@@ -20,23 +20,78 @@ type ReadState func(context.Context, [][]byte) ([][]byte, []error)
 
 // Metadata
 // 0x0/ (tx)
-//   -> [txID] => timestamp
+//   -> [txID] => timestamp|success|units
+// 0x1/ (collection nfts)
+//   -> [collection|id] => nil
+// 0x2/ (memos)
+//   -> [actionID] => memo
 //
 // State
 // 0x0/ (balance)
 //   -> [owner|asset] => balance
 // 0x1/ (assets)
-//   -> [asset] => metadataLen|metadata|supply|owner|warp
+//   -> [asset] => symbolLen|symbol|decimals|nameLen|name|uriLen|uri|uriHash|
+//      metadataLen|metadata|supply|maxSupply|owner|warp|regulated|fee|feeRecipient
 // 0x2/ (hypersdk-incoming warp)
 // 0x3/ (hypersdk-outgoing warp)
+// 0x4/ (loans)
+//   -> [assetID|destination] => amount
+// 0x5/ (orders)
+//   -> [txID] => in|inTick|out|outTick|remaining|owner
+// 0x6/ (allowances)
+//   -> [owner|spender|asset] => amount
+// 0x7/ (htlcs)
+//   -> [txID] => sender|to|asset|value|hash|expiry
+// 0x8/ (vestings)
+//   -> [txID] => beneficiary|asset|total|claimed|start|cliff|duration
+// 0x9/ (frozen accounts)
+//   -> [asset|account] => frozen
+// 0xa/ (accrued transfer fees)
+//   -> [asset] => amount
+// 0xb/ (collections)
+//   -> [collection] => metadataLen|metadata|owner|supply
+// 0xc/ (nfts)
+//   -> [collection|id] => owner|metadataLen|metadata
+// 0xd/ (airdrops)
+//   -> [txID] => creator|asset|root|entries|remaining|expiry
+// 0xe/ (airdrop claims)
+//   -> [txID|word] => claimed bitmap
+// 0xf/ (pools)
+//   -> [txID] => assetA|assetB|reserveA|reserveB|fee
+// 0x10/ (streams)
+//   -> [txID] => sender|recipient|asset|deposit|rate|start|withdrawn
+// 0x11/ (multisigs)
+//   -> [account] => threshold|signers
+// 0x12/ (session keys)
+//   -> [account|sessionKey] => expiry|actionsLen|actions|limitsLen|limits(asset|remaining)
+// 0x13/ (burned nfts)
+//   -> [collection|id] => burned
 
 const (
-	txPrefix = 0x0
+	txPrefix            = 0x0
+	collectionNFTPrefix = 0x1
+	memoPrefix          = 0x2
 
 	balancePrefix      = 0x0
 	assetPrefix        = 0x1
 	incomingWarpPrefix = 0x2
 	outgoingWarpPrefix = 0x3
+	loanPrefix         = 0x4
+	orderPrefix        = 0x5
+	allowancePrefix    = 0x6
+	htlcPrefix         = 0x7
+	vestingPrefix      = 0x8
+	frozenPrefix       = 0x9
+	feesPrefix         = 0xa
+	collectionPrefix   = 0xb
+	nftPrefix          = 0xc
+	airdropPrefix      = 0xd
+	airdropClaimPrefix = 0xe
+	poolPrefix         = 0xf
+	streamPrefix       = 0x10
+	multisigPrefix     = 0x11
+	sessionKeyPrefix   = 0x12
+	burnedNFTPrefix    = 0x13
 )
 
 var (
@@ -95,6 +150,89 @@ func GetTransaction(
 	return true, t, success, units, nil
 }
 
+// [memoPrefix] + [actionID]
+func PrefixMemoKey(id ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = memoPrefix
+	copy(k[1:], id[:])
+	return
+}
+
+// StoreMemo indexes the memo of the action with [id], which is the ID of the
+// transaction unless the action is part of a sequence.
+func StoreMemo(
+	_ context.Context,
+	db database.KeyValueWriter,
+	id ids.ID,
+	memo []byte,
+) error {
+	return db.Put(PrefixMemoKey(id), memo)
+}
+
+// GetMemo returns the memo of the action with [id], or nil if it has none.
+func GetMemo(
+	_ context.Context,
+	db database.KeyValueReader,
+	id ids.ID,
+) ([]byte, error) {
+	v, err := db.Get(PrefixMemoKey(id))
+	if errors.Is(err, database.ErrNotFound) {
+		return nil, nil
+	}
+	return v, err
+}
+
+// [collectionNFTPrefix] + [collection] + [id]
+func PrefixCollectionNFTKey(collection ids.ID, id uint64) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint64Len)
+	k[0] = collectionNFTPrefix
+	copy(k[1:], collection[:])
+	binary.BigEndian.PutUint64(k[1+consts.IDLen:], id)
+	return
+}
+
+// StoreCollectionNFT indexes [id] as part of [collection] so that all NFTs in
+// [collection] can be enumerated.
+func StoreCollectionNFT(
+	_ context.Context,
+	db database.KeyValueWriter,
+	collection ids.ID,
+	id uint64,
+) error {
+	return db.Put(PrefixCollectionNFTKey(collection, id), nil)
+}
+
+func RemoveCollectionNFT(
+	_ context.Context,
+	db database.KeyValueDeleter,
+	collection ids.ID,
+	id uint64,
+) error {
+	return db.Delete(PrefixCollectionNFTKey(collection, id))
+}
+
+// GetCollectionNFTs returns up to [limit] NFTs in [collection], starting from
+// [start].
+func GetCollectionNFTs(
+	_ context.Context,
+	db database.Iteratee,
+	collection ids.ID,
+	start uint64,
+	limit int,
+) ([]uint64, error) {
+	prefix := make([]byte, 1+consts.IDLen)
+	prefix[0] = collectionNFTPrefix
+	copy(prefix[1:], collection[:])
+	iter := db.NewIteratorWithStartAndPrefix(PrefixCollectionNFTKey(collection, start), prefix)
+	defer iter.Release()
+
+	nfts := []uint64{}
+	for len(nfts) < limit && iter.Next() {
+		nfts = append(nfts, binary.BigEndian.Uint64(iter.Key()[1+consts.IDLen:]))
+	}
+	return nfts, iter.Error()
+}
+
 // [accountPrefix] + [address] + [asset]
 func PrefixBalanceKey(pk crypto.PublicKey, asset ids.ID) (k []byte) {
 	k = make([]byte, 1+crypto.PublicKeyLen+consts.IDLen)
@@ -223,21 +361,46 @@ func PrefixAssetKey(asset ids.ID) (k []byte) {
 	return
 }
 
+// Asset is the state of an asset. The [Symbol], [Decimals], [Name], [URI] and
+// [URIHash] describe how the asset should be displayed. A [MaxSupply] of 0
+// means that [Supply] is not capped. If [Regulated] is set, the [Owner] can
+// freeze accounts and claw back funds. The [Fee] (in basis points) of each
+// transfer is accrued for the [FeeRecipient].
+type Asset struct {
+	Symbol   []byte
+	Decimals uint8
+	Name     []byte
+	URI      []byte
+	URIHash  ids.ID
+
+	Metadata  []byte
+	Supply    uint64
+	MaxSupply uint64
+	Owner     crypto.PublicKey
+	Warp      bool
+	Regulated bool
+
+	Fee          uint64
+	FeeRecipient crypto.PublicKey
+}
+
 // Used to serve RPC queries
 func GetAssetFromState(
 	ctx context.Context,
 	f ReadState,
 	asset ids.ID,
-) (bool, []byte, uint64, crypto.PublicKey, bool, error) {
+) (bool, *Asset, error) {
 	values, errs := f(ctx, [][]byte{PrefixAssetKey(asset)})
 	return innerGetAsset(values[0], errs[0])
 }
 
+// GetAsset returns the state of [asset]. It returns false (and a nil [Asset])
+// if [asset] doesn't exist.
 func GetAsset(
 	ctx context.Context,
 	db chain.Database,
 	asset ids.ID,
-) (bool, []byte, uint64, crypto.PublicKey, bool, error) {
+) (bool, *Asset, error) {
 	k := PrefixAssetKey(asset)
 	return innerGetAsset(db.GetValue(ctx, k))
 }
@@ -245,43 +408,98 @@ func GetAsset(
 func innerGetAsset(
 	v []byte,
 	err error,
-) (bool, []byte, uint64, crypto.PublicKey, bool, error) {
+) (bool, *Asset, error) {
 	if errors.Is(err, database.ErrNotFound) {
-		return false, nil, 0, crypto.EmptyPublicKey, false, nil
+		return false, nil, nil
 	}
 	if err != nil {
-		return false, nil, 0, crypto.EmptyPublicKey, false, err
+		return false, nil, err
 	}
-	metadataLen := binary.BigEndian.Uint16(v)
-	metadata := v[consts.Uint16Len : consts.Uint16Len+metadataLen]
-	supply := binary.BigEndian.Uint64(v[consts.Uint16Len+metadataLen:])
-	var pk crypto.PublicKey
-	copy(pk[:], v[consts.Uint16Len+metadataLen+consts.Uint64Len:])
-	warp := v[consts.Uint16Len+metadataLen+consts.Uint64Len+crypto.PublicKeyLen] == 0x1
-	return true, metadata, supply, pk, warp, nil
+	var a Asset
+	// The leading fields are variable length, so we track our position in [v]
+	// with [o].
+	symbolLen := int(binary.BigEndian.Uint16(v))
+	o := consts.Uint16Len
+	a.Symbol = v[o : o+symbolLen]
+	o += symbolLen
+	a.Decimals = v[o]
+	o++
+	nameLen := int(binary.BigEndian.Uint16(v[o:]))
+	o += consts.Uint16Len
+	a.Name = v[o : o+nameLen]
+	o += nameLen
+	uriLen := int(binary.BigEndian.Uint16(v[o:]))
+	o += consts.Uint16Len
+	a.URI = v[o : o+uriLen]
+	o += uriLen
+	copy(a.URIHash[:], v[o:])
+	o += consts.IDLen
+	metadataLen := int(binary.BigEndian.Uint16(v[o:]))
+	o += consts.Uint16Len
+	a.Metadata = v[o : o+metadataLen]
+	o += metadataLen
+	a.Supply = binary.BigEndian.Uint64(v[o:])
+	a.MaxSupply = binary.BigEndian.Uint64(v[o+consts.Uint64Len:])
+	copy(a.Owner[:], v[o+consts.Uint64Len*2:])
+	a.Warp = v[o+consts.Uint64Len*2+crypto.PublicKeyLen] == 0x1
+	a.Regulated = v[o+consts.Uint64Len*2+crypto.PublicKeyLen+1] == 0x1
+	a.Fee = binary.BigEndian.Uint64(v[o+consts.Uint64Len*2+crypto.PublicKeyLen+2:])
+	copy(a.FeeRecipient[:], v[o+consts.Uint64Len*3+crypto.PublicKeyLen+2:])
+	return true, &a, nil
 }
 
+// SetAsset stores [a] as the state of [asset].
 func SetAsset(
 	ctx context.Context,
 	db chain.Database,
 	asset ids.ID,
-	metadata []byte,
-	supply uint64,
-	owner crypto.PublicKey,
-	warp bool,
+	a *Asset,
 ) error {
 	k := PrefixAssetKey(asset)
-	metadataLen := len(metadata)
-	v := make([]byte, consts.Uint16Len+metadataLen+consts.Uint64Len+crypto.PublicKeyLen+1)
-	binary.BigEndian.PutUint16(v, uint16(metadataLen))
-	copy(v[consts.Uint16Len:], metadata)
-	binary.BigEndian.PutUint64(v[consts.Uint16Len+metadataLen:], supply)
-	copy(v[consts.Uint16Len+metadataLen+consts.Uint64Len:], owner[:])
+	symbolLen := len(a.Symbol)
+	nameLen := len(a.Name)
+	uriLen := len(a.URI)
+	metadataLen := len(a.Metadata)
+	v := make(
+		[]byte,
+		consts.Uint16Len*4+symbolLen+1+nameLen+uriLen+consts.IDLen+metadataLen+
+			consts.Uint64Len*3+crypto.PublicKeyLen*2+2,
+	)
+	binary.BigEndian.PutUint16(v, uint16(symbolLen))
+	o := consts.Uint16Len
+	copy(v[o:], a.Symbol)
+	o += symbolLen
+	v[o] = a.Decimals
+	o++
+	binary.BigEndian.PutUint16(v[o:], uint16(nameLen))
+	o += consts.Uint16Len
+	copy(v[o:], a.Name)
+	o += nameLen
+	binary.BigEndian.PutUint16(v[o:], uint16(uriLen))
+	o += consts.Uint16Len
+	copy(v[o:], a.URI)
+	o += uriLen
+	copy(v[o:], a.URIHash[:])
+	o += consts.IDLen
+	binary.BigEndian.PutUint16(v[o:], uint16(metadataLen))
+	o += consts.Uint16Len
+	copy(v[o:], a.Metadata)
+	o += metadataLen
+	binary.BigEndian.PutUint64(v[o:], a.Supply)
+	binary.BigEndian.PutUint64(v[o+consts.Uint64Len:], a.MaxSupply)
+	copy(v[o+consts.Uint64Len*2:], a.Owner[:])
 	b := byte(0x0)
-	if warp {
+	if a.Warp {
+		b = 0x1
+	}
+	v[o+consts.Uint64Len*2+crypto.PublicKeyLen] = b
+	b = byte(0x0)
+	if a.Regulated {
 		b = 0x1
 	}
-	v[consts.Uint16Len+metadataLen+consts.Uint64Len+crypto.PublicKeyLen] = b
+	v[o+consts.Uint64Len*2+crypto.PublicKeyLen+1] = b
+	binary.BigEndian.PutUint64(v[o+consts.Uint64Len*2+crypto.PublicKeyLen+2:], a.Fee)
+	copy(v[o+consts.Uint64Len*3+crypto.PublicKeyLen+2:], a.FeeRecipient[:])
 	return db.Insert(ctx, k, v)
 }
 
@@ -290,6 +508,75 @@ func DeleteAsset(ctx context.Context, db chain.Database, asset ids.ID) error {
 	return db.Remove(ctx, k)
 }
 
+// [orderPrefix] + [txID]
+func PrefixOrderKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = orderPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetOrder(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	in ids.ID,
+	inTick uint64,
+	out ids.ID,
+	outTick uint64,
+	remaining uint64,
+	owner crypto.PublicKey,
+) error {
+	k := PrefixOrderKey(txID)
+	v := make([]byte, consts.IDLen*2+consts.Uint64Len*3+crypto.PublicKeyLen)
+	copy(v, in[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen:], inTick)
+	copy(v[consts.IDLen+consts.Uint64Len:], out[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen*2+consts.Uint64Len:], outTick)
+	binary.BigEndian.PutUint64(v[consts.IDLen*2+consts.Uint64Len*2:], remaining)
+	copy(v[consts.IDLen*2+consts.Uint64Len*3:], owner[:])
+	return db.Insert(ctx, k, v)
+}
+
+func GetOrder(
+	ctx context.Context,
+	db chain.Database,
+	order ids.ID,
+) (
+	bool, // exists
+	ids.ID, // in
+	uint64, // inTick
+	ids.ID, // out
+	uint64, // outTick
+	uint64, // remaining
+	crypto.PublicKey, // owner
+	error,
+) {
+	k := PrefixOrderKey(order)
+	v, err := db.GetValue(ctx, k)
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, 0, ids.Empty, 0, 0, crypto.EmptyPublicKey, nil
+	}
+	if err != nil {
+		return false, ids.Empty, 0, ids.Empty, 0, 0, crypto.EmptyPublicKey, err
+	}
+	var in ids.ID
+	copy(in[:], v[:consts.IDLen])
+	inTick := binary.BigEndian.Uint64(v[consts.IDLen:])
+	var out ids.ID
+	copy(out[:], v[consts.IDLen+consts.Uint64Len:consts.IDLen*2+consts.Uint64Len])
+	outTick := binary.BigEndian.Uint64(v[consts.IDLen*2+consts.Uint64Len:])
+	remaining := binary.BigEndian.Uint64(v[consts.IDLen*2+consts.Uint64Len*2:])
+	var owner crypto.PublicKey
+	copy(owner[:], v[consts.IDLen*2+consts.Uint64Len*3:])
+	return true, in, inTick, out, outTick, remaining, owner, nil
+}
+
+func DeleteOrder(ctx context.Context, db chain.Database, order ids.ID) error {
+	k := PrefixOrderKey(order)
+	return db.Remove(ctx, k)
+}
+
 func IncomingWarpKeyPrefix(sourceChainID ids.ID, msgID ids.ID) (k []byte) {
 	k = make([]byte, 1+consts.IDLen*2)
 	k[0] = incomingWarpPrefix
@@ -304,3 +591,1178 @@ func OutgoingWarpKeyPrefix(txID ids.ID) (k []byte) {
 	copy(k[1:], txID[:])
 	return k
 }
+
+// [loanPrefix] + [asset] + [destination]
+func PrefixLoanKey(asset ids.ID, destination ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen*2)
+	k[0] = loanPrefix
+	copy(k[1:], asset[:])
+	copy(k[1+consts.IDLen:], destination[:])
+	return
+}
+
+// Used to serve RPC queries
+func GetLoanFromState(
+	ctx context.Context,
+	f ReadState,
+	asset ids.ID,
+	destination ids.ID,
+) (uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixLoanKey(asset, destination)})
+	return innerGetLoan(values[0], errs[0])
+}
+
+func innerGetLoan(v []byte, err error) (uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func GetLoan(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	destination ids.ID,
+) (uint64, error) {
+	k := PrefixLoanKey(asset, destination)
+	return innerGetLoan(db.GetValue(ctx, k))
+}
+
+func SetLoan(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	destination ids.ID,
+	amount uint64,
+) error {
+	k := PrefixLoanKey(asset, destination)
+	return db.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, amount))
+}
+
+func AddLoan(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	destination ids.ID,
+	amount uint64,
+) error {
+	loan, err := GetLoan(ctx, db, asset, destination)
+	if err != nil {
+		return err
+	}
+	nloan, err := smath.Add64(loan, amount)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: could not add loan (asset=%s, destination=%s, amount=%d)",
+			ErrInvalidBalance,
+			asset,
+			destination,
+			amount,
+		)
+	}
+	return SetLoan(ctx, db, asset, destination, nloan)
+}
+
+func SubLoan(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	destination ids.ID,
+	amount uint64,
+) error {
+	loan, err := GetLoan(ctx, db, asset, destination)
+	if err != nil {
+		return err
+	}
+	nloan, err := smath.Sub(loan, amount)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: could not subtract loan (asset=%s, destination=%s, amount=%d)",
+			ErrInvalidBalance,
+			asset,
+			destination,
+			amount,
+		)
+	}
+	if nloan == 0 {
+		// If there is no balance left, we should delete the record instead of
+		// setting it to 0.
+		return db.Remove(ctx, PrefixLoanKey(asset, destination))
+	}
+	return SetLoan(ctx, db, asset, destination, nloan)
+}
+
+// [allowancePrefix] + [owner] + [spender] + [asset]
+func PrefixAllowanceKey(owner crypto.PublicKey, spender crypto.PublicKey, asset ids.ID) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen*2+consts.IDLen)
+	k[0] = allowancePrefix
+	copy(k[1:], owner[:])
+	copy(k[1+crypto.PublicKeyLen:], spender[:])
+	copy(k[1+crypto.PublicKeyLen*2:], asset[:])
+	return
+}
+
+// Used to serve RPC queries
+func GetAllowanceFromState(
+	ctx context.Context,
+	f ReadState,
+	owner crypto.PublicKey,
+	spender crypto.PublicKey,
+	asset ids.ID,
+) (uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixAllowanceKey(owner, spender, asset)})
+	return innerGetAllowance(values[0], errs[0])
+}
+
+func innerGetAllowance(v []byte, err error) (uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func GetAllowance(
+	ctx context.Context,
+	db chain.Database,
+	owner crypto.PublicKey,
+	spender crypto.PublicKey,
+	asset ids.ID,
+) (uint64, error) {
+	k := PrefixAllowanceKey(owner, spender, asset)
+	return innerGetAllowance(db.GetValue(ctx, k))
+}
+
+func SetAllowance(
+	ctx context.Context,
+	db chain.Database,
+	owner crypto.PublicKey,
+	spender crypto.PublicKey,
+	asset ids.ID,
+	amount uint64,
+) error {
+	k := PrefixAllowanceKey(owner, spender, asset)
+	if amount == 0 {
+		// If there is no allowance left, we should delete the record instead
+		// of setting it to 0.
+		return db.Remove(ctx, k)
+	}
+	return db.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, amount))
+}
+
+func SubAllowance(
+	ctx context.Context,
+	db chain.Database,
+	owner crypto.PublicKey,
+	spender crypto.PublicKey,
+	asset ids.ID,
+	amount uint64,
+) error {
+	allowance, err := GetAllowance(ctx, db, owner, spender, asset)
+	if err != nil {
+		return err
+	}
+	nallowance, err := smath.Sub(allowance, amount)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: could not subtract allowance (asset=%s, allowance=%d, amount=%d)",
+			ErrInsufficientAllowance,
+			asset,
+			allowance,
+			amount,
+		)
+	}
+	return SetAllowance(ctx, db, owner, spender, asset, nallowance)
+}
+
+// [htlcPrefix] + [txID]
+func PrefixHTLCKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = htlcPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetHTLC(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	sender crypto.PublicKey,
+	to crypto.PublicKey,
+	asset ids.ID,
+	value uint64,
+	hash ids.ID,
+	expiry int64,
+) error {
+	k := PrefixHTLCKey(txID)
+	v := make([]byte, crypto.PublicKeyLen*2+consts.IDLen*2+consts.Uint64Len*2)
+	copy(v, sender[:])
+	copy(v[crypto.PublicKeyLen:], to[:])
+	copy(v[crypto.PublicKeyLen*2:], asset[:])
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen*2+consts.IDLen:], value)
+	copy(v[crypto.PublicKeyLen*2+consts.IDLen+consts.Uint64Len:], hash[:])
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen*2+consts.IDLen*2+consts.Uint64Len:], uint64(expiry))
+	return db.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetHTLCFromState(
+	ctx context.Context,
+	f ReadState,
+	txID ids.ID,
+) (bool, crypto.PublicKey, crypto.PublicKey, ids.ID, uint64, ids.ID, int64, error) {
+	values, errs := f(ctx, [][]byte{PrefixHTLCKey(txID)})
+	return innerGetHTLC(values[0], errs[0])
+}
+
+func GetHTLC(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+) (
+	bool, // exists
+	crypto.PublicKey, // sender
+	crypto.PublicKey, // to
+	ids.ID, // asset
+	uint64, // value
+	ids.ID, // hash
+	int64, // expiry
+	error,
+) {
+	k := PrefixHTLCKey(txID)
+	return innerGetHTLC(db.GetValue(ctx, k))
+}
+
+func innerGetHTLC(
+	v []byte,
+	err error,
+) (bool, crypto.PublicKey, crypto.PublicKey, ids.ID, uint64, ids.ID, int64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, crypto.EmptyPublicKey, crypto.EmptyPublicKey, ids.Empty, 0, ids.Empty, 0, nil
+	}
+	if err != nil {
+		return false, crypto.EmptyPublicKey, crypto.EmptyPublicKey, ids.Empty, 0, ids.Empty, 0, err
+	}
+	var sender crypto.PublicKey
+	copy(sender[:], v[:crypto.PublicKeyLen])
+	var to crypto.PublicKey
+	copy(to[:], v[crypto.PublicKeyLen:crypto.PublicKeyLen*2])
+	var asset ids.ID
+	copy(asset[:], v[crypto.PublicKeyLen*2:crypto.PublicKeyLen*2+consts.IDLen])
+	value := binary.BigEndian.Uint64(v[crypto.PublicKeyLen*2+consts.IDLen:])
+	var hash ids.ID
+	copy(hash[:], v[crypto.PublicKeyLen*2+consts.IDLen+consts.Uint64Len:crypto.PublicKeyLen*2+consts.IDLen*2+consts.Uint64Len])
+	expiry := int64(binary.BigEndian.Uint64(v[crypto.PublicKeyLen*2+consts.IDLen*2+consts.Uint64Len:]))
+	return true, sender, to, asset, value, hash, expiry, nil
+}
+
+func DeleteHTLC(ctx context.Context, db chain.Database, txID ids.ID) error {
+	k := PrefixHTLCKey(txID)
+	return db.Remove(ctx, k)
+}
+
+// [vestingPrefix] + [txID]
+func PrefixVestingKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = vestingPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetVesting(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	beneficiary crypto.PublicKey,
+	asset ids.ID,
+	total uint64,
+	claimed uint64,
+	start int64,
+	cliff int64,
+	duration int64,
+) error {
+	k := PrefixVestingKey(txID)
+	v := make([]byte, crypto.PublicKeyLen+consts.IDLen+consts.Uint64Len*5)
+	copy(v, beneficiary[:])
+	copy(v[crypto.PublicKeyLen:], asset[:])
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+consts.IDLen:], total)
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+consts.IDLen+consts.Uint64Len:], claimed)
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+consts.IDLen+consts.Uint64Len*2:], uint64(start))
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+consts.IDLen+consts.Uint64Len*3:], uint64(cliff))
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+consts.IDLen+consts.Uint64Len*4:], uint64(duration))
+	return db.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetVestingFromState(
+	ctx context.Context,
+	f ReadState,
+	txID ids.ID,
+) (bool, crypto.PublicKey, ids.ID, uint64, uint64, int64, int64, int64, error) {
+	values, errs := f(ctx, [][]byte{PrefixVestingKey(txID)})
+	return innerGetVesting(values[0], errs[0])
+}
+
+func GetVesting(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+) (
+	bool, // exists
+	crypto.PublicKey, // beneficiary
+	ids.ID, // asset
+	uint64, // total
+	uint64, // claimed
+	int64, // start
+	int64, // cliff
+	int64, // duration
+	error,
+) {
+	k := PrefixVestingKey(txID)
+	return innerGetVesting(db.GetValue(ctx, k))
+}
+
+func innerGetVesting(
+	v []byte,
+	err error,
+) (bool, crypto.PublicKey, ids.ID, uint64, uint64, int64, int64, int64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, crypto.EmptyPublicKey, ids.Empty, 0, 0, 0, 0, 0, nil
+	}
+	if err != nil {
+		return false, crypto.EmptyPublicKey, ids.Empty, 0, 0, 0, 0, 0, err
+	}
+	var beneficiary crypto.PublicKey
+	copy(beneficiary[:], v[:crypto.PublicKeyLen])
+	var asset ids.ID
+	copy(asset[:], v[crypto.PublicKeyLen:crypto.PublicKeyLen+consts.IDLen])
+	total := binary.BigEndian.Uint64(v[crypto.PublicKeyLen+consts.IDLen:])
+	claimed := binary.BigEndian.Uint64(v[crypto.PublicKeyLen+consts.IDLen+consts.Uint64Len:])
+	start := int64(binary.BigEndian.Uint64(v[crypto.PublicKeyLen+consts.IDLen+consts.Uint64Len*2:]))
+	cliff := int64(binary.BigEndian.Uint64(v[crypto.PublicKeyLen+consts.IDLen+consts.Uint64Len*3:]))
+	duration := int64(binary.BigEndian.Uint64(v[crypto.PublicKeyLen+consts.IDLen+consts.Uint64Len*4:]))
+	return true, beneficiary, asset, total, claimed, start, cliff, duration, nil
+}
+
+func DeleteVesting(ctx context.Context, db chain.Database, txID ids.ID) error {
+	k := PrefixVestingKey(txID)
+	return db.Remove(ctx, k)
+}
+
+// [frozenPrefix] + [asset] + [account]
+func PrefixFrozenKey(asset ids.ID, account crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+crypto.PublicKeyLen)
+	k[0] = frozenPrefix
+	copy(k[1:], asset[:])
+	copy(k[1+consts.IDLen:], account[:])
+	return
+}
+
+// Used to serve RPC queries
+func GetFrozenFromState(
+	ctx context.Context,
+	f ReadState,
+	asset ids.ID,
+	account crypto.PublicKey,
+) (bool, error) {
+	values, errs := f(ctx, [][]byte{PrefixFrozenKey(asset, account)})
+	return innerGetFrozen(values[0], errs[0])
+}
+
+func GetFrozen(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	account crypto.PublicKey,
+) (bool, error) {
+	k := PrefixFrozenKey(asset, account)
+	return innerGetFrozen(db.GetValue(ctx, k))
+}
+
+func innerGetFrozen(v []byte, err error) (bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return v[0] == 0x1, nil
+}
+
+// SetFrozen freezes [account] for [asset]. Accounts that are not frozen do
+// not have a record.
+func SetFrozen(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	account crypto.PublicKey,
+) error {
+	k := PrefixFrozenKey(asset, account)
+	return db.Insert(ctx, k, []byte{0x1})
+}
+
+func DeleteFrozen(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	account crypto.PublicKey,
+) error {
+	k := PrefixFrozenKey(asset, account)
+	return db.Remove(ctx, k)
+}
+
+// [feesPrefix] + [asset]
+func PrefixFeesKey(asset ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = feesPrefix
+	copy(k[1:], asset[:])
+	return
+}
+
+// Used to serve RPC queries
+func GetFeesFromState(
+	ctx context.Context,
+	f ReadState,
+	asset ids.ID,
+) (uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixFeesKey(asset)})
+	return innerGetFees(values[0], errs[0])
+}
+
+func innerGetFees(v []byte, err error) (uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(v), nil
+}
+
+func GetFees(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+) (uint64, error) {
+	k := PrefixFeesKey(asset)
+	return innerGetFees(db.GetValue(ctx, k))
+}
+
+func AddFees(
+	ctx context.Context,
+	db chain.Database,
+	asset ids.ID,
+	amount uint64,
+) error {
+	fees, err := GetFees(ctx, db, asset)
+	if err != nil {
+		return err
+	}
+	nfees, err := smath.Add64(fees, amount)
+	if err != nil {
+		return fmt.Errorf(
+			"%w: could not add fees (asset=%s, fees=%d, amount=%d)",
+			ErrInvalidBalance,
+			asset,
+			fees,
+			amount,
+		)
+	}
+	k := PrefixFeesKey(asset)
+	return db.Insert(ctx, k, binary.BigEndian.AppendUint64(nil, nfees))
+}
+
+func DeleteFees(ctx context.Context, db chain.Database, asset ids.ID) error {
+	k := PrefixFeesKey(asset)
+	return db.Remove(ctx, k)
+}
+
+// [collectionPrefix] + [collection]
+func PrefixCollectionKey(collection ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = collectionPrefix
+	copy(k[1:], collection[:])
+	return
+}
+
+// Used to serve RPC queries
+func GetCollectionFromState(
+	ctx context.Context,
+	f ReadState,
+	collection ids.ID,
+) (bool, []byte, crypto.PublicKey, uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixCollectionKey(collection)})
+	return innerGetCollection(values[0], errs[0])
+}
+
+func GetCollection(
+	ctx context.Context,
+	db chain.Database,
+	collection ids.ID,
+) (
+	bool, // exists
+	[]byte, // metadata
+	crypto.PublicKey, // owner
+	uint64, // supply
+	error,
+) {
+	k := PrefixCollectionKey(collection)
+	return innerGetCollection(db.GetValue(ctx, k))
+}
+
+func innerGetCollection(
+	v []byte,
+	err error,
+) (bool, []byte, crypto.PublicKey, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil, crypto.EmptyPublicKey, 0, nil
+	}
+	if err != nil {
+		return false, nil, crypto.EmptyPublicKey, 0, err
+	}
+	metadataLen := binary.BigEndian.Uint16(v)
+	metadata := v[consts.Uint16Len : consts.Uint16Len+metadataLen]
+	var owner crypto.PublicKey
+	copy(owner[:], v[consts.Uint16Len+metadataLen:])
+	supply := binary.BigEndian.Uint64(v[consts.Uint16Len+int(metadataLen)+crypto.PublicKeyLen:])
+	return true, metadata, owner, supply, nil
+}
+
+// SetCollection stores [collection]. [supply] is the number of NFTs in
+// [collection] that have not been burned.
+func SetCollection(
+	ctx context.Context,
+	db chain.Database,
+	collection ids.ID,
+	metadata []byte,
+	owner crypto.PublicKey,
+	supply uint64,
+) error {
+	k := PrefixCollectionKey(collection)
+	metadataLen := len(metadata)
+	v := make([]byte, consts.Uint16Len+metadataLen+crypto.PublicKeyLen+consts.Uint64Len)
+	binary.BigEndian.PutUint16(v, uint16(metadataLen))
+	copy(v[consts.Uint16Len:], metadata)
+	copy(v[consts.Uint16Len+metadataLen:], owner[:])
+	binary.BigEndian.PutUint64(v[consts.Uint16Len+metadataLen+crypto.PublicKeyLen:], supply)
+	return db.Insert(ctx, k, v)
+}
+
+// [nftPrefix] + [collection] + [id]
+func PrefixNFTKey(collection ids.ID, id uint64) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint64Len)
+	k[0] = nftPrefix
+	copy(k[1:], collection[:])
+	binary.BigEndian.PutUint64(k[1+consts.IDLen:], id)
+	return
+}
+
+// Used to serve RPC queries
+func GetNFTFromState(
+	ctx context.Context,
+	f ReadState,
+	collection ids.ID,
+	id uint64,
+) (bool, crypto.PublicKey, []byte, error) {
+	values, errs := f(ctx, [][]byte{PrefixNFTKey(collection, id)})
+	return innerGetNFT(values[0], errs[0])
+}
+
+func GetNFT(
+	ctx context.Context,
+	db chain.Database,
+	collection ids.ID,
+	id uint64,
+) (
+	bool, // exists
+	crypto.PublicKey, // owner
+	[]byte, // metadata
+	error,
+) {
+	k := PrefixNFTKey(collection, id)
+	return innerGetNFT(db.GetValue(ctx, k))
+}
+
+func innerGetNFT(v []byte, err error) (bool, crypto.PublicKey, []byte, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, crypto.EmptyPublicKey, nil, nil
+	}
+	if err != nil {
+		return false, crypto.EmptyPublicKey, nil, err
+	}
+	var owner crypto.PublicKey
+	copy(owner[:], v[:crypto.PublicKeyLen])
+	metadataLen := binary.BigEndian.Uint16(v[crypto.PublicKeyLen:])
+	metadata := v[crypto.PublicKeyLen+consts.Uint16Len : crypto.PublicKeyLen+consts.Uint16Len+int(metadataLen)]
+	return true, owner, metadata, nil
+}
+
+func SetNFT(
+	ctx context.Context,
+	db chain.Database,
+	collection ids.ID,
+	id uint64,
+	owner crypto.PublicKey,
+	metadata []byte,
+) error {
+	k := PrefixNFTKey(collection, id)
+	metadataLen := len(metadata)
+	v := make([]byte, crypto.PublicKeyLen+consts.Uint16Len+metadataLen)
+	copy(v, owner[:])
+	binary.BigEndian.PutUint16(v[crypto.PublicKeyLen:], uint16(metadataLen))
+	copy(v[crypto.PublicKeyLen+consts.Uint16Len:], metadata)
+	return db.Insert(ctx, k, v)
+}
+
+func DeleteNFT(
+	ctx context.Context,
+	db chain.Database,
+	collection ids.ID,
+	id uint64,
+) error {
+	k := PrefixNFTKey(collection, id)
+	return db.Remove(ctx, k)
+}
+
+// [burnedNFTPrefix] + [collection] + [id]
+func PrefixBurnedNFTKey(collection ids.ID, id uint64) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint64Len)
+	k[0] = burnedNFTPrefix
+	copy(k[1:], collection[:])
+	binary.BigEndian.PutUint64(k[1+consts.IDLen:], id)
+	return
+}
+
+func GetNFTBurned(
+	ctx context.Context,
+	db chain.Database,
+	collection ids.ID,
+	id uint64,
+) (bool, error) {
+	k := PrefixBurnedNFTKey(collection, id)
+	v, err := db.GetValue(ctx, k)
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return v[0] == 0x1, nil
+}
+
+// SetNFTBurned records that [id] was burned, so it can't be minted again
+// after its record is deleted.
+func SetNFTBurned(
+	ctx context.Context,
+	db chain.Database,
+	collection ids.ID,
+	id uint64,
+) error {
+	k := PrefixBurnedNFTKey(collection, id)
+	return db.Insert(ctx, k, []byte{0x1})
+}
+
+// [airdropPrefix] + [txID]
+func PrefixAirdropKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = airdropPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetAirdrop(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	creator crypto.PublicKey,
+	asset ids.ID,
+	root ids.ID,
+	entries uint64,
+	remaining uint64,
+	expiry int64,
+) error {
+	k := PrefixAirdropKey(txID)
+	v := make([]byte, crypto.PublicKeyLen+consts.IDLen*2+consts.Uint64Len*3)
+	copy(v, creator[:])
+	copy(v[crypto.PublicKeyLen:], asset[:])
+	copy(v[crypto.PublicKeyLen+consts.IDLen:], root[:])
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+consts.IDLen*2:], entries)
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+consts.IDLen*2+consts.Uint64Len:], remaining)
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen+consts.IDLen*2+consts.Uint64Len*2:], uint64(expiry))
+	return db.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetAirdropFromState(
+	ctx context.Context,
+	f ReadState,
+	txID ids.ID,
+) (bool, crypto.PublicKey, ids.ID, ids.ID, uint64, uint64, int64, error) {
+	values, errs := f(ctx, [][]byte{PrefixAirdropKey(txID)})
+	return innerGetAirdrop(values[0], errs[0])
+}
+
+func GetAirdrop(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+) (
+	bool, // exists
+	crypto.PublicKey, // creator
+	ids.ID, // asset
+	ids.ID, // root
+	uint64, // entries
+	uint64, // remaining
+	int64, // expiry
+	error,
+) {
+	k := PrefixAirdropKey(txID)
+	return innerGetAirdrop(db.GetValue(ctx, k))
+}
+
+func innerGetAirdrop(
+	v []byte,
+	err error,
+) (bool, crypto.PublicKey, ids.ID, ids.ID, uint64, uint64, int64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, crypto.EmptyPublicKey, ids.Empty, ids.Empty, 0, 0, 0, nil
+	}
+	if err != nil {
+		return false, crypto.EmptyPublicKey, ids.Empty, ids.Empty, 0, 0, 0, err
+	}
+	var creator crypto.PublicKey
+	copy(creator[:], v[:crypto.PublicKeyLen])
+	var asset ids.ID
+	copy(asset[:], v[crypto.PublicKeyLen:crypto.PublicKeyLen+consts.IDLen])
+	var root ids.ID
+	copy(root[:], v[crypto.PublicKeyLen+consts.IDLen:crypto.PublicKeyLen+consts.IDLen*2])
+	entries := binary.BigEndian.Uint64(v[crypto.PublicKeyLen+consts.IDLen*2:])
+	remaining := binary.BigEndian.Uint64(v[crypto.PublicKeyLen+consts.IDLen*2+consts.Uint64Len:])
+	expiry := int64(binary.BigEndian.Uint64(v[crypto.PublicKeyLen+consts.IDLen*2+consts.Uint64Len*2:]))
+	return true, creator, asset, root, entries, remaining, expiry, nil
+}
+
+func DeleteAirdrop(ctx context.Context, db chain.Database, txID ids.ID) error {
+	k := PrefixAirdropKey(txID)
+	return db.Remove(ctx, k)
+}
+
+// airdropClaimWordBits is the number of claims tracked by each airdrop claim
+// key.
+const airdropClaimWordBits = 256
+
+// [airdropClaimPrefix] + [txID] + [index / airdropClaimWordBits]
+//
+// Claims are tracked in a bitmap, so each key records whether
+// [airdropClaimWordBits] consecutive entries have been claimed.
+func PrefixAirdropClaimKey(txID ids.ID, index uint64) (k []byte) {
+	k = make([]byte, 1+consts.IDLen+consts.Uint64Len)
+	k[0] = airdropClaimPrefix
+	copy(k[1:], txID[:])
+	binary.BigEndian.PutUint64(k[1+consts.IDLen:], index/airdropClaimWordBits)
+	return
+}
+
+// PrefixAirdropClaimKeys returns every key of the claims bitmap of an airdrop
+// with [entries].
+func PrefixAirdropClaimKeys(txID ids.ID, entries uint64) [][]byte {
+	keys := make([][]byte, 0, (entries+airdropClaimWordBits-1)/airdropClaimWordBits)
+	for index := uint64(0); index < entries; index += airdropClaimWordBits {
+		keys = append(keys, PrefixAirdropClaimKey(txID, index))
+	}
+	return keys
+}
+
+// Used to serve RPC queries
+func GetAirdropClaimedFromState(
+	ctx context.Context,
+	f ReadState,
+	txID ids.ID,
+	index uint64,
+) (bool, error) {
+	values, errs := f(ctx, [][]byte{PrefixAirdropClaimKey(txID, index)})
+	return innerGetAirdropClaimed(values[0], errs[0], index)
+}
+
+func GetAirdropClaimed(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	index uint64,
+) (bool, error) {
+	k := PrefixAirdropClaimKey(txID, index)
+	v, err := db.GetValue(ctx, k)
+	return innerGetAirdropClaimed(v, err, index)
+}
+
+func innerGetAirdropClaimed(v []byte, err error, index uint64) (bool, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	bit := index % airdropClaimWordBits
+	return v[bit/8]&(1<<(bit%8)) != 0, nil
+}
+
+func SetAirdropClaimed(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	index uint64,
+) error {
+	k := PrefixAirdropClaimKey(txID, index)
+	v, err := db.GetValue(ctx, k)
+	if errors.Is(err, database.ErrNotFound) {
+		v = make([]byte, airdropClaimWordBits/8)
+	} else if err != nil {
+		return err
+	}
+	// Don't modify the value returned by the database in place
+	nv := make([]byte, len(v))
+	copy(nv, v)
+	bit := index % airdropClaimWordBits
+	nv[bit/8] |= 1 << (bit % 8)
+	return db.Insert(ctx, k, nv)
+}
+
+func DeleteAirdropClaims(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	entries uint64,
+) error {
+	for _, k := range PrefixAirdropClaimKeys(txID, entries) {
+		if err := db.Remove(ctx, k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// [poolPrefix] + [txID]
+func PrefixPoolKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = poolPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetPool(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	assetA ids.ID,
+	assetB ids.ID,
+	reserveA uint64,
+	reserveB uint64,
+	fee uint64,
+) error {
+	k := PrefixPoolKey(txID)
+	v := make([]byte, consts.IDLen*2+consts.Uint64Len*3)
+	copy(v, assetA[:])
+	copy(v[consts.IDLen:], assetB[:])
+	binary.BigEndian.PutUint64(v[consts.IDLen*2:], reserveA)
+	binary.BigEndian.PutUint64(v[consts.IDLen*2+consts.Uint64Len:], reserveB)
+	binary.BigEndian.PutUint64(v[consts.IDLen*2+consts.Uint64Len*2:], fee)
+	return db.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetPoolFromState(
+	ctx context.Context,
+	f ReadState,
+	txID ids.ID,
+) (bool, ids.ID, ids.ID, uint64, uint64, uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixPoolKey(txID)})
+	return innerGetPool(values[0], errs[0])
+}
+
+func GetPool(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+) (
+	bool, // exists
+	ids.ID, // assetA
+	ids.ID, // assetB
+	uint64, // reserveA
+	uint64, // reserveB
+	uint64, // fee
+	error,
+) {
+	k := PrefixPoolKey(txID)
+	return innerGetPool(db.GetValue(ctx, k))
+}
+
+func innerGetPool(
+	v []byte,
+	err error,
+) (bool, ids.ID, ids.ID, uint64, uint64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, ids.Empty, ids.Empty, 0, 0, 0, nil
+	}
+	if err != nil {
+		return false, ids.Empty, ids.Empty, 0, 0, 0, err
+	}
+	var assetA ids.ID
+	copy(assetA[:], v[:consts.IDLen])
+	var assetB ids.ID
+	copy(assetB[:], v[consts.IDLen:consts.IDLen*2])
+	reserveA := binary.BigEndian.Uint64(v[consts.IDLen*2:])
+	reserveB := binary.BigEndian.Uint64(v[consts.IDLen*2+consts.Uint64Len:])
+	fee := binary.BigEndian.Uint64(v[consts.IDLen*2+consts.Uint64Len*2:])
+	return true, assetA, assetB, reserveA, reserveB, fee, nil
+}
+
+// [streamPrefix] + [txID]
+func PrefixStreamKey(txID ids.ID) (k []byte) {
+	k = make([]byte, 1+consts.IDLen)
+	k[0] = streamPrefix
+	copy(k[1:], txID[:])
+	return
+}
+
+func SetStream(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+	sender crypto.PublicKey,
+	recipient crypto.PublicKey,
+	asset ids.ID,
+	deposit uint64,
+	rate uint64,
+	start int64,
+	withdrawn uint64,
+) error {
+	k := PrefixStreamKey(txID)
+	v := make([]byte, crypto.PublicKeyLen*2+consts.IDLen+consts.Uint64Len*4)
+	copy(v, sender[:])
+	copy(v[crypto.PublicKeyLen:], recipient[:])
+	copy(v[crypto.PublicKeyLen*2:], asset[:])
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen*2+consts.IDLen:], deposit)
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen*2+consts.IDLen+consts.Uint64Len:], rate)
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen*2+consts.IDLen+consts.Uint64Len*2:], uint64(start))
+	binary.BigEndian.PutUint64(v[crypto.PublicKeyLen*2+consts.IDLen+consts.Uint64Len*3:], withdrawn)
+	return db.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetStreamFromState(
+	ctx context.Context,
+	f ReadState,
+	txID ids.ID,
+) (bool, crypto.PublicKey, crypto.PublicKey, ids.ID, uint64, uint64, int64, uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixStreamKey(txID)})
+	return innerGetStream(values[0], errs[0])
+}
+
+func GetStream(
+	ctx context.Context,
+	db chain.Database,
+	txID ids.ID,
+) (
+	bool, // exists
+	crypto.PublicKey, // sender
+	crypto.PublicKey, // recipient
+	ids.ID, // asset
+	uint64, // deposit
+	uint64, // rate
+	int64, // start
+	uint64, // withdrawn
+	error,
+) {
+	k := PrefixStreamKey(txID)
+	return innerGetStream(db.GetValue(ctx, k))
+}
+
+func innerGetStream(
+	v []byte,
+	err error,
+) (bool, crypto.PublicKey, crypto.PublicKey, ids.ID, uint64, uint64, int64, uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, crypto.EmptyPublicKey, crypto.EmptyPublicKey, ids.Empty, 0, 0, 0, 0, nil
+	}
+	if err != nil {
+		return false, crypto.EmptyPublicKey, crypto.EmptyPublicKey, ids.Empty, 0, 0, 0, 0, err
+	}
+	var sender crypto.PublicKey
+	copy(sender[:], v[:crypto.PublicKeyLen])
+	var recipient crypto.PublicKey
+	copy(recipient[:], v[crypto.PublicKeyLen:crypto.PublicKeyLen*2])
+	var asset ids.ID
+	copy(asset[:], v[crypto.PublicKeyLen*2:crypto.PublicKeyLen*2+consts.IDLen])
+	deposit := binary.BigEndian.Uint64(v[crypto.PublicKeyLen*2+consts.IDLen:])
+	rate := binary.BigEndian.Uint64(v[crypto.PublicKeyLen*2+consts.IDLen+consts.Uint64Len:])
+	start := int64(binary.BigEndian.Uint64(v[crypto.PublicKeyLen*2+consts.IDLen+consts.Uint64Len*2:]))
+	withdrawn := binary.BigEndian.Uint64(v[crypto.PublicKeyLen*2+consts.IDLen+consts.Uint64Len*3:])
+	return true, sender, recipient, asset, deposit, rate, start, withdrawn, nil
+}
+
+func DeleteStream(ctx context.Context, db chain.Database, txID ids.ID) error {
+	k := PrefixStreamKey(txID)
+	return db.Remove(ctx, k)
+}
+
+// [multisigPrefix] + [account]
+func PrefixMultisigKey(account crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen)
+	k[0] = multisigPrefix
+	copy(k[1:], account[:])
+	return
+}
+
+func SetMultisig(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+	threshold uint8,
+	signers []crypto.PublicKey,
+) error {
+	k := PrefixMultisigKey(account)
+	v := make([]byte, 1+len(signers)*crypto.PublicKeyLen)
+	v[0] = threshold
+	for i, signer := range signers {
+		copy(v[1+i*crypto.PublicKeyLen:], signer[:])
+	}
+	return db.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetMultisigFromState(
+	ctx context.Context,
+	f ReadState,
+	account crypto.PublicKey,
+) (bool, uint8, []crypto.PublicKey, error) {
+	values, errs := f(ctx, [][]byte{PrefixMultisigKey(account)})
+	return innerGetMultisig(values[0], errs[0])
+}
+
+func GetMultisig(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+) (
+	bool, // exists
+	uint8, // threshold
+	[]crypto.PublicKey, // signers
+	error,
+) {
+	k := PrefixMultisigKey(account)
+	return innerGetMultisig(db.GetValue(ctx, k))
+}
+
+func innerGetMultisig(v []byte, err error) (bool, uint8, []crypto.PublicKey, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, nil, nil
+	}
+	if err != nil {
+		return false, 0, nil, err
+	}
+	threshold := v[0]
+	signers := make([]crypto.PublicKey, (len(v)-1)/crypto.PublicKeyLen)
+	for i := range signers {
+		copy(signers[i][:], v[1+i*crypto.PublicKeyLen:])
+	}
+	return true, threshold, signers, nil
+}
+
+// [sessionKeyPrefix] + [account] + [sessionKey]
+func PrefixSessionKeyKey(account crypto.PublicKey, sessionKey crypto.PublicKey) (k []byte) {
+	k = make([]byte, 1+crypto.PublicKeyLen*2)
+	k[0] = sessionKeyPrefix
+	copy(k[1:], account[:])
+	copy(k[1+crypto.PublicKeyLen:], sessionKey[:])
+	return
+}
+
+// SetSessionKey stores a session key of [account]. [remaining] is the amount
+// of each of [assets] that can still be spent with the session key.
+func SetSessionKey(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+	sessionKey crypto.PublicKey,
+	expiry int64,
+	actions []uint8,
+	assets []ids.ID,
+	remaining []uint64,
+) error {
+	k := PrefixSessionKeyKey(account, sessionKey)
+	v := make([]byte, consts.Uint64Len+1+len(actions)+1+len(assets)*(consts.IDLen+consts.Uint64Len))
+	binary.BigEndian.PutUint64(v, uint64(expiry))
+	o := consts.Uint64Len
+	v[o] = uint8(len(actions))
+	o++
+	copy(v[o:], actions)
+	o += len(actions)
+	v[o] = uint8(len(assets))
+	o++
+	for i, asset := range assets {
+		copy(v[o:], asset[:])
+		binary.BigEndian.PutUint64(v[o+consts.IDLen:], remaining[i])
+		o += consts.IDLen + consts.Uint64Len
+	}
+	return db.Insert(ctx, k, v)
+}
+
+// Used to serve RPC queries
+func GetSessionKeyFromState(
+	ctx context.Context,
+	f ReadState,
+	account crypto.PublicKey,
+	sessionKey crypto.PublicKey,
+) (bool, int64, []uint8, []ids.ID, []uint64, error) {
+	values, errs := f(ctx, [][]byte{PrefixSessionKeyKey(account, sessionKey)})
+	return innerGetSessionKey(values[0], errs[0])
+}
+
+func GetSessionKey(
+	ctx context.Context,
+	db chain.Database,
+	account crypto.PublicKey,
+	sessionKey crypto.PublicKey,
+) (
+	bool, // exists
+	int64, // expiry
+	[]uint8, // actions
+	[]ids.ID, // assets
+	[]uint64, // remaining
+	error,
+) {
+	k := PrefixSessionKeyKey(account, sessionKey)
+	return innerGetSessionKey(db.GetValue(ctx, k))
+}
+
+func innerGetSessionKey(v []byte, err error) (bool, int64, []uint8, []ids.ID, []uint64, error) {
+	if errors.Is(err, database.ErrNotFound) {
+		return false, 0, nil, nil, nil, nil
+	}
+	if err != nil {
+		return false, 0, nil, nil, nil, err
+	}
+	expiry := int64(binary.BigEndian.Uint64(v))
+	o := consts.Uint64Len
+	actionsLen := int(v[o])
+	o++
+	actions := make([]uint8, actionsLen)
+	copy(actions, v[o:o+actionsLen])
+	o += actionsLen
+	assetsLen := int(v[o])
+	o++
+	assets := make([]ids.ID, assetsLen)
+	remaining := make([]uint64, assetsLen)
+	for i := 0; i < assetsLen; i++ {
+		copy(assets[i][:], v[o:o+consts.IDLen])
+		remaining[i] = binary.BigEndian.Uint64(v[o+consts.IDLen:])
+		o += consts.IDLen + consts.Uint64Len
+	}
+	return true, expiry, actions, assets, remaining, nil
+}
@@ -1,25 +1,34 @@
 package integration_test
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"net/http/httptest"
 	"os"
+	"sort"
 	"testing"
 	"time"
 
 	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/manager"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	smblock "github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
 	avago_version "github.com/ava-labs/avalanchego/version"
@@ -112,6 +121,29 @@ var (
 	asset2ID ids.ID
 	asset3   []byte
 	asset3ID ids.ID
+	asset4ID ids.ID
+	asset5ID ids.ID
+	asset6ID ids.ID
+
+	collection1ID ids.ID
+
+	airdrop1ID     ids.ID
+	airdrop1Expiry int64
+	airdrop1Proofs [][]ids.ID
+
+	pool1ID ids.ID
+
+	stream1ID ids.ID
+
+	// warpSourceChainID is a chain with a single validator that signs the
+	// warp messages it sends with [warpSigner]
+	warpSourceChainID ids.ID
+	warpSigner        warp.Signer
+
+	warpAsset   ids.ID
+	warpAssetID ids.ID
+	warpMsg     *warp.Message
+	swapAssetID ids.ID
 
 	// when used with embedded VMs
 	genesisBytes []byte
@@ -180,6 +212,39 @@ var _ = ginkgo.BeforeSuite(func() {
 	subnetID := ids.GenerateTestID()
 	chainID := ids.GenerateTestID()
 
+	// Only the validator set of [warpSourceChainID] is known, so warp messages
+	// from any other chain can't be verified
+	warpSourceChainID = ids.GenerateTestID()
+	warpSubnetID := ids.GenerateTestID()
+	warpSK, err := bls.NewSecretKey()
+	gomega.Ω(err).Should(gomega.BeNil())
+	warpSigner = warp.NewSigner(warpSK, warpSourceChainID)
+	warpNodeID := ids.GenerateTestNodeID()
+	vdrState := &validators.TestState{
+		GetSubnetIDF: func(_ context.Context, chainID ids.ID) (ids.ID, error) {
+			if chainID != warpSourceChainID {
+				return ids.Empty, database.ErrNotFound
+			}
+			return warpSubnetID, nil
+		},
+		GetValidatorSetF: func(
+			_ context.Context,
+			_ uint64,
+			subnetID ids.ID,
+		) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+			if subnetID != warpSubnetID {
+				return nil, database.ErrNotFound
+			}
+			return map[ids.NodeID]*validators.GetValidatorOutput{
+				warpNodeID: {
+					NodeID:    warpNodeID,
+					PublicKey: bls.PublicFromSecretKey(warpSK),
+					Weight:    1,
+				},
+			}, nil
+		},
+	}
+
 	app := &appSender{}
 	for i := range instances {
 		nodeID := ids.GenerateTestNodeID()
@@ -199,7 +264,7 @@ var _ = ginkgo.BeforeSuite(func() {
 			Metrics:        metrics.NewOptionalGatherer(),
 			PublicKey:      bls.PublicFromSecretKey(sk),
 			WarpSigner:     warp.NewSigner(sk, chainID),
-			ValidatorState: &validators.TestState{},
+			ValidatorState: vdrState,
 		}
 
 		toEngine := make(chan common.Message, 1)
@@ -253,13 +318,13 @@ var _ = ginkgo.BeforeSuite(func() {
 			gomega.Ω(balance).Should(gomega.Equal(alloc.Balance))
 			csupply += alloc.Balance
 		}
-		exists, metadata, supply, owner, warp, err := cli.Asset(context.Background(), ids.Empty)
+		exists, asset, err := cli.Asset(context.Background(), ids.Empty)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(exists).Should(gomega.BeTrue())
-		gomega.Ω(string(metadata)).Should(gomega.Equal(tconsts.Symbol))
-		gomega.Ω(supply).Should(gomega.Equal(csupply))
-		gomega.Ω(owner).Should(gomega.Equal(utils.Address(crypto.EmptyPublicKey)))
-		gomega.Ω(warp).Should(gomega.BeFalse())
+		gomega.Ω(string(asset.Metadata)).Should(gomega.Equal(tconsts.Symbol))
+		gomega.Ω(asset.Supply).Should(gomega.Equal(csupply))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(utils.Address(crypto.EmptyPublicKey)))
+		gomega.Ω(asset.Warp).Should(gomega.BeFalse())
 	}
 
 	app.instances = instances
@@ -690,7 +755,7 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(string(result.Output)).
 			Should(gomega.ContainSubstring("asset missing"))
 
-		exists, _, _, _, _, err := instances[0].cli.Asset(context.TODO(), assetID)
+		exists, _, err := instances[0].cli.Asset(context.TODO(), assetID)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(exists).Should(gomega.BeFalse())
 	})
@@ -700,6 +765,8 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			nil,
 			&actions.CreateAsset{
+				Symbol:   []byte("ASSET"),
+				Name:     []byte("asset"),
 				Metadata: nil,
 			},
 			factory,
@@ -715,16 +782,16 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		balance, err := instances[0].cli.Balance(context.TODO(), sender, assetID)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
-		exists, metadata, supply, owner, warp, err := instances[0].cli.Asset(
+		exists, asset, err := instances[0].cli.Asset(
 			context.TODO(),
 			assetID,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(exists).Should(gomega.BeTrue())
-		gomega.Ω(metadata).Should(gomega.HaveLen(0))
-		gomega.Ω(supply).Should(gomega.Equal(uint64(0)))
-		gomega.Ω(owner).Should(gomega.Equal(sender))
-		gomega.Ω(warp).Should(gomega.BeFalse())
+		gomega.Ω(asset.Metadata).Should(gomega.HaveLen(0))
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(0)))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(sender))
+		gomega.Ω(asset.Warp).Should(gomega.BeFalse())
 	})
 
 	ginkgo.It("create asset with too long of metadata", func() {
@@ -737,6 +804,8 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			},
 			nil,
 			&actions.CreateAsset{
+				Symbol:   []byte("ASSET"),
+				Name:     []byte("asset"),
 				Metadata: make([]byte, actions.MaxMetadataSize*2),
 			},
 		)
@@ -762,6 +831,8 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			nil,
 			&actions.CreateAsset{
+				Symbol:   []byte("ASSET"),
+				Name:     []byte("asset"),
 				Metadata: asset1,
 			},
 			factory,
@@ -778,16 +849,16 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
 
-		exists, metadata, supply, owner, warp, err := instances[0].cli.Asset(
+		exists, asset, err := instances[0].cli.Asset(
 			context.TODO(),
 			asset1ID,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(exists).Should(gomega.BeTrue())
-		gomega.Ω(metadata).Should(gomega.Equal(asset1))
-		gomega.Ω(supply).Should(gomega.Equal(uint64(0)))
-		gomega.Ω(owner).Should(gomega.Equal(sender))
-		gomega.Ω(warp).Should(gomega.BeFalse())
+		gomega.Ω(asset.Metadata).Should(gomega.Equal(asset1))
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(0)))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(sender))
+		gomega.Ω(asset.Warp).Should(gomega.BeFalse())
 	})
 
 	ginkgo.It("mint a new asset", func() {
@@ -815,16 +886,16 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
 
-		exists, metadata, supply, owner, warp, err := instances[0].cli.Asset(
+		exists, asset, err := instances[0].cli.Asset(
 			context.TODO(),
 			asset1ID,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(exists).Should(gomega.BeTrue())
-		gomega.Ω(metadata).Should(gomega.Equal(asset1))
-		gomega.Ω(supply).Should(gomega.Equal(uint64(15)))
-		gomega.Ω(owner).Should(gomega.Equal(sender))
-		gomega.Ω(warp).Should(gomega.BeFalse())
+		gomega.Ω(asset.Metadata).Should(gomega.Equal(asset1))
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(15)))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(sender))
+		gomega.Ω(asset.Warp).Should(gomega.BeFalse())
 	})
 
 	ginkgo.It("mint asset from wrong owner", func() {
@@ -850,16 +921,16 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(string(result.Output)).
 			Should(gomega.ContainSubstring("wrong owner"))
 
-		exists, metadata, supply, owner, warp, err := instances[0].cli.Asset(
+		exists, asset, err := instances[0].cli.Asset(
 			context.TODO(),
 			asset1ID,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(exists).Should(gomega.BeTrue())
-		gomega.Ω(metadata).Should(gomega.Equal(asset1))
-		gomega.Ω(supply).Should(gomega.Equal(uint64(15)))
-		gomega.Ω(owner).Should(gomega.Equal(sender))
-		gomega.Ω(warp).Should(gomega.BeFalse())
+		gomega.Ω(asset.Metadata).Should(gomega.Equal(asset1))
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(15)))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(sender))
+		gomega.Ω(asset.Warp).Should(gomega.BeFalse())
 	})
 
 	ginkgo.It("rejects empty mint", func() {
@@ -918,21 +989,21 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 
 		balance, err := instances[0].cli.Balance(context.TODO(), sender2, asset1ID)
 		gomega.Ω(err).Should(gomega.BeNil())
-		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
+		gomega.Ω(balance).Should(gomega.Equal(uint64(15)))
 		balance, err = instances[0].cli.Balance(context.TODO(), sender, asset1ID)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
 
-		exists, metadata, supply, owner, warp, err := instances[0].cli.Asset(
+		exists, asset, err := instances[0].cli.Asset(
 			context.TODO(),
 			asset1ID,
 		)
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(exists).Should(gomega.BeTrue())
-		gomega.Ω(metadata).Should(gomega.Equal(asset1))
-		gomega.Ω(supply).Should(gomega.Equal(uint64(10)))
-		gomega.Ω(owner).Should(gomega.Equal(sender))
-		gomega.Ω(warp).Should(gomega.BeFalse())
+		gomega.Ω(asset.Metadata).Should(gomega.Equal(asset1))
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(15)))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(sender))
+		gomega.Ω(asset.Warp).Should(gomega.BeFalse())
 	})
 
 	ginkgo.It("rejects mint of native token", func() {
@@ -973,6 +1044,8 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			nil,
 			&actions.CreateAsset{
+				Symbol:   []byte("ASSET"),
+				Name:     []byte("asset"),
 				Metadata: asset2,
 			},
 			factory,
@@ -1012,6 +1085,8 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 			context.Background(),
 			nil,
 			&actions.CreateAsset{
+				Symbol:   []byte("ASSET"),
+				Name:     []byte("asset"),
 				Metadata: asset3,
 			},
 			factory2,
@@ -1045,35 +1120,3741 @@ var _ = ginkgo.Describe("[Tx Processing]", func() {
 		gomega.Ω(err).Should(gomega.BeNil())
 		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
 	})
-})
 
-func expectBlk(i instance) func() []*chain.Result {
-	ctx := context.TODO()
+	ginkgo.It("burn an asset", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.BurnAsset{
+				Asset: asset2ID,
+				Value: 4,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
 
-	// manually signal ready
-	i.vm.Builder().TriggerBuild()
-	// manually ack ready sig as in engine
-	<-i.toEngine
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(6)))
 
-	blk, err := i.vm.BuildBlock(ctx)
-	gomega.Ω(err).To(gomega.BeNil())
-	gomega.Ω(blk).To(gomega.Not(gomega.BeNil()))
+		exists, asset, err := instances[0].cli.Asset(
+			context.TODO(),
+			asset2ID,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Metadata).Should(gomega.Equal(asset2))
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(6)))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(sender))
+		gomega.Ω(asset.Warp).Should(gomega.BeFalse())
+	})
 
-	gomega.Ω(blk.Verify(ctx)).To(gomega.BeNil())
-	gomega.Ω(blk.Status()).To(gomega.Equal(choices.Processing))
+	ginkgo.It("rejects burn of more than balance", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.BurnAsset{
+				Asset: asset2ID,
+				Value: 7,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("invalid balance"))
 
-	err = i.vm.SetPreference(ctx, blk.ID())
-	gomega.Ω(err).To(gomega.BeNil())
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(6)))
 
-	return func() []*chain.Result {
-		gomega.Ω(blk.Accept(ctx)).To(gomega.BeNil())
-		gomega.Ω(blk.Status()).To(gomega.Equal(choices.Accepted))
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(6)))
+	})
 
-		lastAccepted, err := i.vm.LastAccepted(ctx)
-		gomega.Ω(err).To(gomega.BeNil())
-		gomega.Ω(lastAccepted).To(gomega.Equal(blk.ID()))
-		return blk.(*chain.StatelessBlock).Results()
-	}
+	ginkgo.It("modify an asset", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ModifyAsset{
+				Asset:    asset1ID,
+				Metadata: []byte("renamed"),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		exists, asset, err := instances[0].cli.Asset(
+			context.TODO(),
+			asset1ID,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Metadata).Should(gomega.Equal([]byte("renamed")))
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(15)))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(sender))
+		gomega.Ω(asset.Warp).Should(gomega.BeFalse())
+	})
+
+	ginkgo.It("modify asset from wrong owner", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ModifyAsset{
+				Asset:    asset1ID,
+				Metadata: []byte("hijacked"),
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("wrong owner"))
+
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), asset1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Metadata).Should(gomega.Equal([]byte("renamed")))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(sender))
+	})
+
+	ginkgo.It("export an asset", func() {
+		destination := ids.GenerateTestID()
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ExportAsset{
+				To:          rsender2,
+				Asset:       asset2ID,
+				Value:       2,
+				Reward:      1,
+				Destination: destination,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		gomega.Ω(result.WarpMessage).ShouldNot(gomega.BeNil())
+		gomega.Ω(result.WarpMessage.DestinationChainID).Should(gomega.Equal(destination))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(3)))
+		loan, err := instances[0].cli.Loan(context.TODO(), asset2ID, destination)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(loan).Should(gomega.Equal(uint64(3)))
+		loan, err = instances[0].cli.Loan(context.TODO(), asset2ID, ids.GenerateTestID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(loan).Should(gomega.Equal(uint64(0)))
+	})
+
+	ginkgo.It("rejects return of a non-warp asset", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ExportAsset{
+				To:          rsender2,
+				Asset:       asset2ID,
+				Value:       1,
+				Return:      true,
+				Destination: ids.GenerateTestID(),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("not warp asset"))
+	})
+	ginkgo.It("import an asset from another chain", func() {
+		warpAsset = ids.GenerateTestID()
+		warpAssetID = actions.ImportedAssetID(warpAsset, warpSourceChainID)
+		warpMsg = newWarpMessage(warpSourceChainID, &actions.WarpTransfer{
+			To:       rsender2,
+			Asset:    warpAsset,
+			Symbol:   []byte("WARP"),
+			Decimals: 2,
+			Value:    100,
+			Reward:   10,
+			TxID:     ids.GenerateTestID(),
+		}, warpSigner)
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			warpMsg,
+			&actions.ImportAsset{},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectWarpBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, warpAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(100)))
+		// The reward is paid to the actor that imported the message
+		balance, err = instances[0].cli.Balance(context.TODO(), sender, warpAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
+
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), warpAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Symbol).Should(gomega.Equal([]byte("WARP")))
+		gomega.Ω(asset.Decimals).Should(gomega.Equal(uint8(2)))
+		gomega.Ω(asset.Metadata).
+			Should(gomega.Equal(actions.ImportedAssetMetadata(warpAsset, warpSourceChainID)))
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(110)))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(utils.Address(crypto.EmptyPublicKey)))
+		gomega.Ω(asset.Warp).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("rejects a replayed warp message", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			warpMsg,
+			&actions.ImportAsset{},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectWarpBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("warp verification failed"))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, warpAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(100)))
+	})
+
+	ginkgo.It("rejects an import with an unsigned warp message", func() {
+		wm := newWarpMessage(warpSourceChainID, &actions.WarpTransfer{
+			To:       rsender2,
+			Asset:    warpAsset,
+			Symbol:   []byte("WARP"),
+			Decimals: 2,
+			Value:    100,
+			TxID:     ids.GenerateTestID(),
+		}, nil)
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			wm,
+			&actions.ImportAsset{},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectWarpBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("warp verification failed"))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, warpAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(100)))
+	})
+
+	ginkgo.It("rejects an import with an unverified warp message", func() {
+		// The validators of [sourceChainID] are unknown, so its signature
+		// can't be verified
+		sourceChainID := ids.GenerateTestID()
+		sk, err := bls.NewSecretKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		wm := newWarpMessage(sourceChainID, &actions.WarpTransfer{
+			To:       rsender2,
+			Asset:    warpAsset,
+			Symbol:   []byte("WARP"),
+			Decimals: 2,
+			Value:    100,
+			TxID:     ids.GenerateTestID(),
+		}, warp.NewSigner(sk, sourceChainID))
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			wm,
+			&actions.ImportAsset{},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectWarpBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("warp verification failed"))
+
+		balance, err := instances[0].cli.Balance(
+			context.TODO(),
+			sender2,
+			actions.ImportedAssetID(warpAsset, sourceChainID),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
+	})
+
+	ginkgo.It("return an asset to its native chain", func() {
+		submit, exportTx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ExportAsset{
+				To:          rsender2,
+				Asset:       ids.Empty,
+				Value:       1_000,
+				Reward:      100,
+				Destination: warpSourceChainID,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		loan, err := instances[0].cli.Loan(context.TODO(), ids.Empty, warpSourceChainID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(loan).Should(gomega.Equal(uint64(1_100)))
+
+		// [warpSourceChainID] sends the loan back to a new account
+		other, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		wm := newWarpMessage(warpSourceChainID, &actions.WarpTransfer{
+			To:       other.PublicKey(),
+			Asset:    ids.Empty,
+			Symbol:   []byte(tconsts.Symbol),
+			Decimals: tconsts.Decimals,
+			Value:    1_000,
+			Return:   true,
+			Reward:   100,
+			TxID:     exportTx.ID(),
+		}, warpSigner)
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			wm,
+			&actions.ImportAsset{},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectWarpBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+
+		loan, err = instances[0].cli.Loan(context.TODO(), ids.Empty, warpSourceChainID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(loan).Should(gomega.Equal(uint64(0)))
+		otherBalance, err := instances[0].cli.Balance(
+			context.TODO(),
+			utils.Address(other.PublicKey()),
+			ids.Empty,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(otherBalance).Should(gomega.Equal(uint64(1_000)))
+		// The reward is paid to the actor that imported the message
+		newBalance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(newBalance).Should(gomega.Equal(balance + 100 - result.Units*tx.Base.UnitPrice))
+	})
+
+	ginkgo.It("create an asset to fill swaps with", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAsset{
+				Symbol: []byte("SWAP"),
+				Name:   []byte("swap"),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		swapAssetID = tx.ID()
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintAsset{
+				To:    rsender,
+				Asset: swapAssetID,
+				Value: 1_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("rejects an import with a swap that must be filled", func() {
+		wm := newWarpMessage(warpSourceChainID, &actions.WarpTransfer{
+			To:         rsender2,
+			Asset:      warpAsset,
+			Symbol:     []byte("WARP"),
+			Decimals:   2,
+			Value:      100,
+			SwapIn:     40,
+			AssetOut:   swapAssetID,
+			SwapOut:    400,
+			SwapExpiry: time.Now().Add(time.Hour).Unix(),
+			TxID:       ids.GenerateTestID(),
+		}, warpSigner)
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			wm,
+			&actions.ImportAsset{},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectWarpBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("must fill request"))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, warpAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(100)))
+	})
+
+	ginkgo.It("import without filling an expired swap", func() {
+		wm := newWarpMessage(warpSourceChainID, &actions.WarpTransfer{
+			To:         rsender2,
+			Asset:      warpAsset,
+			Symbol:     []byte("WARP"),
+			Decimals:   2,
+			Value:      100,
+			SwapIn:     40,
+			AssetOut:   swapAssetID,
+			SwapOut:    400,
+			SwapExpiry: time.Now().Add(-time.Hour).Unix(),
+			TxID:       ids.GenerateTestID(),
+		}, warpSigner)
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			wm,
+			&actions.ImportAsset{},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectWarpBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, warpAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(200)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender2, swapAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
+	})
+
+	ginkgo.It("fill the swap of an import", func() {
+		wm := newWarpMessage(warpSourceChainID, &actions.WarpTransfer{
+			To:         rsender2,
+			Asset:      warpAsset,
+			Symbol:     []byte("WARP"),
+			Decimals:   2,
+			Value:      100,
+			SwapIn:     40,
+			AssetOut:   swapAssetID,
+			SwapOut:    400,
+			SwapExpiry: time.Now().Add(time.Hour).Unix(),
+			TxID:       ids.GenerateTestID(),
+		}, warpSigner)
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			wm,
+			&actions.ImportAsset{Fill: true},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectWarpBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// [SwapIn] of the imported asset is exchanged for [SwapOut] of
+		// [AssetOut] with the actor
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, warpAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(260)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender, warpAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(50)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender2, swapAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(400)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender, swapAssetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(600)))
+	})
+
+	var order1ID ids.ID
+	ginkgo.It("create an order", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateOrder{
+				In:      asset3ID,
+				InTick:  2,
+				Out:     asset2ID,
+				OutTick: 1,
+				Supply:  2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		order1ID = tx.ID()
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(1)))
+
+		orders, err := instances[0].cli.Orders(context.TODO(), actions.PairID(asset3ID, asset2ID))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(orders).Should(gomega.HaveLen(1))
+		order := orders[0]
+		gomega.Ω(order.ID).Should(gomega.Equal(order1ID))
+		gomega.Ω(order.InTick).Should(gomega.Equal(uint64(2)))
+		gomega.Ω(order.OutTick).Should(gomega.Equal(uint64(1)))
+		gomega.Ω(order.Owner).Should(gomega.Equal(sender))
+		gomega.Ω(order.Remaining).Should(gomega.Equal(uint64(2)))
+	})
+
+	ginkgo.It("rejects order with misaligned supply", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateOrder{
+				In:      asset3ID,
+				InTick:  1,
+				Out:     asset2ID,
+				OutTick: 2,
+				Supply:  1,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("supply is misaligned"))
+	})
+
+	ginkgo.It("fill part of an order", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.FillOrder{
+				Order: order1ID,
+				Owner: rsender,
+				In:    asset3ID,
+				Out:   asset2ID,
+				Value: 2,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		or, err := actions.UnmarshalOrderResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(or.In).Should(gomega.Equal(uint64(2)))
+		gomega.Ω(or.Out).Should(gomega.Equal(uint64(1)))
+		gomega.Ω(or.Remaining).Should(gomega.Equal(uint64(1)))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset3ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(2)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender2, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(1)))
+
+		orders, err := instances[0].cli.Orders(context.TODO(), actions.PairID(asset3ID, asset2ID))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(orders).Should(gomega.HaveLen(1))
+		gomega.Ω(orders[0].Remaining).Should(gomega.Equal(uint64(1)))
+	})
+
+	ginkgo.It("close order from wrong owner", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CloseOrder{
+				Order: order1ID,
+				Out:   asset2ID,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("unauthorized"))
+	})
+
+	ginkgo.It("close an order", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CloseOrder{
+				Order: order1ID,
+				Out:   asset2ID,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(2)))
+
+		orders, err := instances[0].cli.Orders(context.TODO(), actions.PairID(asset3ID, asset2ID))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(orders).Should(gomega.HaveLen(0))
+	})
+
+	ginkgo.It("transfer asset ownership", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.TransferAssetOwnership{
+				Asset: asset1ID,
+				To:    rsender2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		exists, asset, err := instances[0].cli.Asset(
+			context.TODO(),
+			asset1ID,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Metadata).Should(gomega.Equal([]byte("renamed")))
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(15)))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(sender2))
+		gomega.Ω(asset.Warp).Should(gomega.BeFalse())
+
+		// Previous owner can no longer mint
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintAsset{
+				To:    rsender,
+				Asset: asset1ID,
+				Value: 1,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("wrong owner"))
+
+		// New owner can mint
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintAsset{
+				To:    rsender2,
+				Asset: asset1ID,
+				Value: 5,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, asset1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(20)))
+	})
+
+	ginkgo.It("rejects transfer of asset ownership to empty owner", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.TransferAssetOwnership{
+				Asset: asset1ID,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("new owner is empty"))
+	})
+
+	ginkgo.It("renounce asset ownership", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.TransferAssetOwnership{
+				Asset:    asset1ID,
+				Renounce: true,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), asset1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(20)))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(utils.Address(crypto.EmptyPublicKey)))
+
+		// Supply is now fixed
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintAsset{
+				To:    rsender2,
+				Asset: asset1ID,
+				Value: 1,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("wrong owner"))
+	})
+
+	ginkgo.It("enforces max supply on mint", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAsset{
+				Symbol:    []byte("ASSET"),
+				Name:      []byte("asset"),
+				Metadata:  []byte("capped"),
+				MaxSupply: 5,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		assetID := tx.ID()
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintAsset{
+				To:    rsender,
+				Asset: assetID,
+				Value: 5,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintAsset{
+				To:    rsender,
+				Asset: assetID,
+				Value: 1,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("max supply exceeded"))
+
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(5)))
+		gomega.Ω(asset.MaxSupply).Should(gomega.Equal(uint64(5)))
+	})
+
+	ginkgo.It("batch transfer to multiple recipients", func() {
+		nativeBalance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.BatchTransfer{
+				Entries: []*actions.BatchTransferEntry{
+					{To: rsender2, Asset: asset2ID, Value: 1},
+					{To: rsender2, Asset: ids.Empty, Value: 100},
+				},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(1)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender2, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(2)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(nativeBalance + 100))
+	})
+
+	ginkgo.It("rejects batch transfer if any entry fails", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.BatchTransfer{
+				Entries: []*actions.BatchTransferEntry{
+					{To: rsender2, Asset: asset2ID, Value: 1},
+					{To: rsender2, Asset: asset2ID, Value: 1},
+				},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("invalid balance"))
+
+		// First entry should not have been applied
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(1)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender2, asset2ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(2)))
+	})
+
+	ginkgo.It("transfer with a memo", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.TransferWithMemo{
+				To:    rsender2,
+				Value: 1,
+				Memo:  []byte("invoice-42"),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		gomega.Ω(result.Units).Should(gomega.Equal(uint64(transferTxFee + len("invoice-42"))))
+
+		found, success, _, memo, err := instances[0].cli.Tx(context.TODO(), tx.ID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(found).Should(gomega.BeTrue())
+		gomega.Ω(success).Should(gomega.BeTrue())
+		gomega.Ω(memo).Should(gomega.Equal([]byte("invoice-42")))
+	})
+
+	ginkgo.It("approve a spender", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Approve{
+				Spender: rsender2,
+				Asset:   asset3ID,
+				Value:   2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		allowance, err := instances[0].cli.Allowance(context.TODO(), sender, sender2, asset3ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(allowance).Should(gomega.Equal(uint64(2)))
+		allowance, err = instances[0].cli.Allowance(context.TODO(), sender2, sender, asset3ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(allowance).Should(gomega.Equal(uint64(0)))
+	})
+
+	ginkgo.It("transfer from an owner", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.TransferFrom{
+				Owner: rsender,
+				To:    rsender2,
+				Asset: asset3ID,
+				Value: 1,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset3ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(1)))
+		allowance, err := instances[0].cli.Allowance(context.TODO(), sender, sender2, asset3ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(allowance).Should(gomega.Equal(uint64(1)))
+	})
+
+	ginkgo.It("rejects transfer from above allowance", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.TransferFrom{
+				Owner: rsender,
+				To:    rsender2,
+				Asset: asset3ID,
+				Value: 2,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("insufficient allowance"))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset3ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(1)))
+	})
+
+	var htlc1ID ids.ID
+	htlcSecret := []byte("secret")
+	ginkgo.It("lock funds in an htlc", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.LockHTLC{
+				To:     rsender2,
+				Asset:  asset3ID,
+				Value:  1,
+				Hash:   hutils.ToID(htlcSecret),
+				Expiry: time.Now().Unix() + 3600,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		htlc1ID = tx.ID()
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset3ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(0)))
+
+		exists, htlc, err := instances[0].cli.HTLC(context.TODO(), htlc1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(htlc.Sender).Should(gomega.Equal(sender))
+		gomega.Ω(htlc.To).Should(gomega.Equal(sender2))
+		gomega.Ω(htlc.Value).Should(gomega.Equal(uint64(1)))
+	})
+
+	ginkgo.It("rejects htlc refund before expiry", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.RefundHTLC{
+				HTLC:  htlc1ID,
+				Asset: asset3ID,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("htlc has not expired"))
+	})
+
+	ginkgo.It("rejects htlc claim with wrong preimage", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ClaimHTLC{
+				HTLC:     htlc1ID,
+				Asset:    asset3ID,
+				Preimage: []byte("guess"),
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("wrong preimage"))
+	})
+
+	ginkgo.It("claim an htlc", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ClaimHTLC{
+				HTLC:     htlc1ID,
+				Asset:    asset3ID,
+				Preimage: htlcSecret,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, asset3ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
+		exists, _, err := instances[0].cli.HTLC(context.TODO(), htlc1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeFalse())
+	})
+
+	ginkgo.It("refund an expired htlc", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.LockHTLC{
+				To:     rsender2,
+				Value:  1000,
+				Hash:   hutils.ToID(htlcSecret),
+				Expiry: time.Now().Unix() + 2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		htlcID := tx.ID()
+
+		// Wait for the htlc to expire
+		time.Sleep(3 * time.Second)
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ClaimHTLC{
+				HTLC:     htlcID,
+				Preimage: htlcSecret,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("htlc has expired"))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.RefundHTLC{
+				HTLC: htlcID,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("claim from a vesting schedule before the cliff", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateVesting{
+				Beneficiary: rsender,
+				Asset:       asset3ID,
+				Value:       5,
+				Start:       time.Now().Unix(),
+				Cliff:       1000,
+				Duration:    2000,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		vestingID := tx.ID()
+
+		exists, vesting, err := instances[0].cli.Vesting(context.TODO(), vestingID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(vesting.Beneficiary).Should(gomega.Equal(sender))
+		gomega.Ω(vesting.Total).Should(gomega.Equal(uint64(5)))
+		gomega.Ω(vesting.Vested).Should(gomega.Equal(uint64(0)))
+		gomega.Ω(vesting.Unvested).Should(gomega.Equal(uint64(5)))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ClaimVested{
+				Vesting: vestingID,
+				Asset:   asset3ID,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("nothing vested"))
+	})
+
+	ginkgo.It("claim from a fully vested schedule", func() {
+		for _, assetID := range []ids.ID{asset3ID, ids.Empty} {
+			submit, tx, _, err := instances[0].cli.GenerateTransaction(
+				context.Background(),
+				nil,
+				&actions.CreateVesting{
+					Beneficiary: rsender,
+					Asset:       assetID,
+					Value:       5,
+					Start:       time.Now().Unix() - 1000,
+					Cliff:       10,
+					Duration:    100,
+				},
+				factory2,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			accept := expectBlk(instances[0])
+			results := accept()
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+			vestingID := tx.ID()
+
+			exists, vesting, err := instances[0].cli.Vesting(context.TODO(), vestingID)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(exists).Should(gomega.BeTrue())
+			gomega.Ω(vesting.Vested).Should(gomega.Equal(uint64(5)))
+			gomega.Ω(vesting.Unvested).Should(gomega.Equal(uint64(0)))
+
+			submit, _, _, err = instances[0].cli.GenerateTransaction(
+				context.Background(),
+				nil,
+				&actions.ClaimVested{
+					Vesting: vestingID,
+					Asset:   assetID,
+				},
+				factory,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			accept = expectBlk(instances[0])
+			results = accept()
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+			exists, _, err = instances[0].cli.Vesting(context.TODO(), vestingID)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(exists).Should(gomega.BeFalse())
+			if assetID != ids.Empty {
+				balance, err := instances[0].cli.Balance(context.TODO(), sender, assetID)
+				gomega.Ω(err).Should(gomega.BeNil())
+				gomega.Ω(balance).Should(gomega.Equal(uint64(5)))
+			}
+		}
+	})
+
+	ginkgo.It("rejects freeze of an unregulated asset", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.FreezeAccount{
+				Asset:   asset3ID,
+				Account: rsender,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("asset is not regulated"))
+
+		frozen, err := instances[0].cli.Frozen(context.TODO(), asset3ID, sender)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(frozen).Should(gomega.BeFalse())
+	})
+
+	ginkgo.It("freeze an account for a regulated asset", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAsset{
+				Symbol:    []byte("ASSET"),
+				Name:      []byte("asset"),
+				Metadata:  []byte("regulated"),
+				Regulated: true,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		asset4ID = tx.ID()
+
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), asset4ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Regulated).Should(gomega.BeTrue())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintAsset{
+				To:    rsender2,
+				Asset: asset4ID,
+				Value: 10,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.FreezeAccount{
+				Asset:   asset4ID,
+				Account: rsender2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		frozen, err := instances[0].cli.Frozen(context.TODO(), asset4ID, sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(frozen).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("rejects freeze from wrong owner", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.FreezeAccount{
+				Asset:   asset4ID,
+				Account: rsender,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("wrong owner"))
+	})
+
+	ginkgo.It("rejects transfer from a frozen account", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rsender,
+				Asset: asset4ID,
+				Value: 1,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("account is frozen"))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, asset4ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
+	})
+
+	ginkgo.It("rejects mint to a frozen account", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintAsset{
+				To:    rsender2,
+				Asset: asset4ID,
+				Value: 1,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("account is frozen"))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, asset4ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(10)))
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), asset4ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(10)))
+	})
+
+	ginkgo.It("claw back from a frozen account", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Clawback{
+				Asset: asset4ID,
+				From:  rsender2,
+				Value: 4,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset4ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(4)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender2, asset4ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(6)))
+	})
+
+	ginkgo.It("unfreeze an account", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.UnfreezeAccount{
+				Asset:   asset4ID,
+				Account: rsender2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		frozen, err := instances[0].cli.Frozen(context.TODO(), asset4ID, sender2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(frozen).Should(gomega.BeFalse())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rsender,
+				Asset: asset4ID,
+				Value: 2,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset4ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(6)))
+	})
+
+	ginkgo.It("rejects transfer fee without a recipient", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAsset{
+				Symbol:      []byte("ASSET"),
+				Name:        []byte("asset"),
+				Metadata:    []byte("fee"),
+				TransferFee: 100,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("fee recipient is empty"))
+	})
+
+	ginkgo.It("withhold transfer fees", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAsset{
+				Symbol:       []byte("ASSET"),
+				Name:         []byte("asset"),
+				Metadata:     []byte("fee"),
+				TransferFee:  250, // 2.5%
+				FeeRecipient: rsender2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		asset5ID = tx.ID()
+
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), asset5ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Fee).Should(gomega.Equal(uint64(250)))
+		gomega.Ω(asset.FeeRecipient).Should(gomega.Equal(sender2))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintAsset{
+				To:    rsender,
+				Asset: asset5ID,
+				Value: 1000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Asset: asset5ID,
+				Value: 400,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		tr, err := actions.UnmarshalTransferResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(tr.Fee).Should(gomega.Equal(uint64(10)))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset5ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(600)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender2, asset5ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(390)))
+		fees, err := instances[0].cli.Fees(context.TODO(), asset5ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(fees).Should(gomega.Equal(uint64(10)))
+	})
+
+	ginkgo.It("rejects fee claim from wrong recipient", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ClaimFees{
+				Asset: asset5ID,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("unauthorized"))
+	})
+
+	ginkgo.It("claim transfer fees", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ClaimFees{
+				Asset: asset5ID,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, asset5ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(400)))
+		fees, err := instances[0].cli.Fees(context.TODO(), asset5ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(fees).Should(gomega.Equal(uint64(0)))
+	})
+
+	ginkgo.It("create a collection and mint nfts", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateCollection{
+				Metadata: []byte("art"),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		collection1ID = tx.ID()
+
+		for _, id := range []uint64{1, 2} {
+			submit, _, _, err = instances[0].cli.GenerateTransaction(
+				context.Background(),
+				nil,
+				&actions.MintNFT{
+					Collection: collection1ID,
+					ID:         id,
+					To:         rsender,
+					Metadata:   []byte(fmt.Sprintf("piece %d", id)),
+				},
+				factory,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+			accept = expectBlk(instances[0])
+			results = accept()
+			gomega.Ω(results).Should(gomega.HaveLen(1))
+			gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		}
+
+		exists, metadata, owner, supply, err := instances[0].cli.Collection(context.TODO(), collection1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(metadata).Should(gomega.Equal([]byte("art")))
+		gomega.Ω(owner).Should(gomega.Equal(sender))
+		gomega.Ω(supply).Should(gomega.Equal(uint64(2)))
+
+		exists, owner, metadata, err = instances[0].cli.NFT(context.TODO(), collection1ID, 2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(owner).Should(gomega.Equal(sender))
+		gomega.Ω(metadata).Should(gomega.Equal([]byte("piece 2")))
+
+		nfts, err := instances[0].cli.NFTs(context.TODO(), collection1ID, 0)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nfts).Should(gomega.Equal([]uint64{1, 2}))
+	})
+
+	ginkgo.It("rejects duplicate nft mint", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintNFT{
+				Collection: collection1ID,
+				ID:         1,
+				To:         rsender2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("nft already exists"))
+	})
+
+	ginkgo.It("rejects nft mint by wrong owner", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintNFT{
+				Collection: collection1ID,
+				ID:         3,
+				To:         rsender2,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("wrong owner"))
+	})
+
+	ginkgo.It("transfer an nft", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.TransferNFT{
+				Collection: collection1ID,
+				ID:         1,
+				To:         rsender2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		exists, owner, _, err := instances[0].cli.NFT(context.TODO(), collection1ID, 1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(owner).Should(gomega.Equal(sender2))
+
+		// The previous owner can no longer transfer it
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.TransferNFT{
+				Collection: collection1ID,
+				ID:         1,
+				To:         rsender,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("wrong owner"))
+	})
+
+	ginkgo.It("burn an nft", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.BurnNFT{
+				Collection: collection1ID,
+				ID:         2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		exists, _, _, err := instances[0].cli.NFT(context.TODO(), collection1ID, 2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeFalse())
+		exists, _, _, supply, err := instances[0].cli.Collection(context.TODO(), collection1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(supply).Should(gomega.Equal(uint64(1)))
+		nfts, err := instances[0].cli.NFTs(context.TODO(), collection1ID, 0)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nfts).Should(gomega.Equal([]uint64{1}))
+	})
+
+	ginkgo.It("rejects mint of a burned nft", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintNFT{
+				Collection: collection1ID,
+				ID:         2,
+				To:         rsender,
+				Metadata:   []byte("reminted"),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("nft was burned"))
+
+		exists, _, _, err := instances[0].cli.NFT(context.TODO(), collection1ID, 2)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeFalse())
+	})
+
+	ginkgo.It("create an airdrop", func() {
+		other, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		leaves := []ids.ID{
+			actions.AirdropLeaf(0, rsender2, 1000),
+			actions.AirdropLeaf(1, rsender, 500),
+			actions.AirdropLeaf(2, other.PublicKey(), 250),
+		}
+		root, proofs := actions.BuildAirdropTree(leaves)
+		for i, leaf := range leaves {
+			gomega.Ω(actions.VerifyAirdropProof(root, leaf, proofs[i])).Should(gomega.BeTrue())
+		}
+		airdrop1Proofs = proofs
+		airdrop1Expiry = time.Now().Unix() + 5
+
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAirdrop{
+				Value:   1750,
+				Root:    root,
+				Entries: uint64(len(leaves)),
+				Expiry:  airdrop1Expiry,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		airdrop1ID = tx.ID()
+
+		exists, airdrop, err := instances[0].cli.Airdrop(context.TODO(), airdrop1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(airdrop.Creator).Should(gomega.Equal(sender))
+		gomega.Ω(airdrop.Root).Should(gomega.Equal(root))
+		gomega.Ω(airdrop.Entries).Should(gomega.Equal(uint64(3)))
+		gomega.Ω(airdrop.Remaining).Should(gomega.Equal(uint64(1750)))
+	})
+
+	ginkgo.It("claim an airdrop entry", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ClaimAirdrop{
+				Airdrop: airdrop1ID,
+				Index:   0,
+				Amount:  1000,
+				Proof:   airdrop1Proofs[0],
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		claimed, err := instances[0].cli.AirdropClaimed(context.TODO(), airdrop1ID, 0)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(claimed).Should(gomega.BeTrue())
+		claimed, err = instances[0].cli.AirdropClaimed(context.TODO(), airdrop1ID, 1)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(claimed).Should(gomega.BeFalse())
+		_, airdrop, err := instances[0].cli.Airdrop(context.TODO(), airdrop1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(airdrop.Remaining).Should(gomega.Equal(uint64(750)))
+	})
+
+	ginkgo.It("rejects duplicate airdrop claim", func() {
+		// Wait for the next timestamp, otherwise the claim is rejected as a
+		// duplicate transaction instead of a duplicate claim
+		time.Sleep(time.Second)
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ClaimAirdrop{
+				Airdrop: airdrop1ID,
+				Index:   0,
+				Amount:  1000,
+				Proof:   airdrop1Proofs[0],
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("already claimed"))
+	})
+
+	ginkgo.It("rejects airdrop claim with invalid proof", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ClaimAirdrop{
+				Airdrop: airdrop1ID,
+				Index:   1,
+				Amount:  600,
+				Proof:   airdrop1Proofs[1],
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("invalid proof"))
+	})
+
+	ginkgo.It("rejects airdrop claim with an out of range index", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ClaimAirdrop{
+				Airdrop: airdrop1ID,
+				Index:   3,
+				Amount:  500,
+				Proof:   airdrop1Proofs[1],
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("index is out of range"))
+	})
+
+	ginkgo.It("reclaim an expired airdrop", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ReclaimAirdrop{
+				Airdrop: airdrop1ID,
+				Entries: 3,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("airdrop has not expired"))
+
+		// Wait for the airdrop to expire
+		time.Sleep(time.Until(time.Unix(airdrop1Expiry+1, 0)))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ClaimAirdrop{
+				Airdrop: airdrop1ID,
+				Index:   1,
+				Amount:  500,
+				Proof:   airdrop1Proofs[1],
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result = results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("airdrop has expired"))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.ReclaimAirdrop{
+				Airdrop: airdrop1ID,
+				Entries: 3,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		exists, _, err := instances[0].cli.Airdrop(context.TODO(), airdrop1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeFalse())
+		claimed, err := instances[0].cli.AirdropClaimed(context.TODO(), airdrop1ID, 0)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(claimed).Should(gomega.BeFalse())
+	})
+
+	ginkgo.It("rejects pool with the same asset twice", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreatePool{
+				AssetA: ids.Empty,
+				AssetB: ids.Empty,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("same asset used for in and out"))
+	})
+
+	ginkgo.It("create a pool", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAsset{
+				Symbol:   []byte("ASSET"),
+				Name:     []byte("asset"),
+				Metadata: []byte("amm"),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		asset6ID = tx.ID()
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.MintAsset{
+				To:    rsender,
+				Asset: asset6ID,
+				Value: 1_000_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		submit, tx, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreatePool{
+				AssetB: asset6ID,
+				Fee:    30,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		pool1ID = tx.ID()
+
+		exists, pool, err := instances[0].cli.Pool(context.TODO(), pool1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(pool.AssetA).Should(gomega.Equal(ids.Empty))
+		gomega.Ω(pool.AssetB).Should(gomega.Equal(asset6ID))
+		gomega.Ω(pool.Fee).Should(gomega.Equal(uint64(30)))
+		gomega.Ω(pool.Shares).Should(gomega.Equal(uint64(0)))
+
+		// The shares of the pool are a regular asset without an owner
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), pool1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Owner).Should(gomega.Equal(utils.Address(crypto.EmptyPublicKey)))
+	})
+
+	ginkgo.It("add liquidity to a pool", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.AddLiquidity{
+				Pool:    pool1ID,
+				AssetB:  asset6ID,
+				AmountA: 100_000,
+				AmountB: 400_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		lr, err := actions.UnmarshalLiquidityResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(lr.Shares).Should(gomega.Equal(uint64(200_000 - actions.MinimumLiquidity)))
+
+		// Only the amounts matching the ratio of the pool are deposited
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.AddLiquidity{
+				Pool:    pool1ID,
+				AssetB:  asset6ID,
+				AmountA: 10_000,
+				AmountB: 100_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result = results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		lr, err = actions.UnmarshalLiquidityResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(lr.AmountA).Should(gomega.Equal(uint64(10_000)))
+		gomega.Ω(lr.AmountB).Should(gomega.Equal(uint64(40_000)))
+		gomega.Ω(lr.Shares).Should(gomega.Equal(uint64(20_000)))
+
+		_, pool, err := instances[0].cli.Pool(context.TODO(), pool1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(pool.ReserveA).Should(gomega.Equal(uint64(110_000)))
+		gomega.Ω(pool.ReserveB).Should(gomega.Equal(uint64(440_000)))
+		gomega.Ω(pool.Shares).Should(gomega.Equal(uint64(220_000)))
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, pool1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(219_000)))
+	})
+
+	ginkgo.It("rejects swap below minimum output", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Swap{
+				Pool:   pool1ID,
+				In:     asset6ID,
+				Value:  44_000,
+				MinOut: 10_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("output is below minimum"))
+	})
+
+	ginkgo.It("swap through a pool", func() {
+		quote, err := instances[0].cli.Quote(context.TODO(), pool1ID, asset6ID, 44_000)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(quote.Out).Should(gomega.Equal(ids.Empty))
+		gomega.Ω(quote.AmountOut).Should(gomega.Equal(uint64(9_972)))
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Swap{
+				Pool:   pool1ID,
+				In:     asset6ID,
+				Value:  44_000,
+				MinOut: quote.AmountOut,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		sr, err := actions.UnmarshalSwapResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(sr.AmountOut).Should(gomega.Equal(uint64(9_972)))
+
+		_, pool, err := instances[0].cli.Pool(context.TODO(), pool1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(pool.ReserveA).Should(gomega.Equal(uint64(100_028)))
+		gomega.Ω(pool.ReserveB).Should(gomega.Equal(uint64(484_000)))
+	})
+
+	ginkgo.It("remove liquidity from a pool", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.RemoveLiquidity{
+				Pool:   pool1ID,
+				AssetB: asset6ID,
+				Shares: 20_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		lr, err := actions.UnmarshalLiquidityResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(lr.AmountA).Should(gomega.Equal(uint64(9_093)))
+		gomega.Ω(lr.AmountB).Should(gomega.Equal(uint64(44_000)))
+
+		_, pool, err := instances[0].cli.Pool(context.TODO(), pool1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(pool.Shares).Should(gomega.Equal(uint64(200_000)))
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, pool1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(199_000)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender, asset6ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(560_000)))
+	})
+
+	ginkgo.It("create a stream", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateStream{
+				Recipient: rsender2,
+				Asset:     asset6ID,
+				Value:     1000,
+				Rate:      100,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		stream1ID = tx.ID()
+
+		exists, stream, err := instances[0].cli.Stream(context.TODO(), stream1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(stream.Sender).Should(gomega.Equal(sender))
+		gomega.Ω(stream.Recipient).Should(gomega.Equal(sender2))
+		gomega.Ω(stream.Deposit).Should(gomega.Equal(uint64(1000)))
+		gomega.Ω(stream.Rate).Should(gomega.Equal(uint64(100)))
+		gomega.Ω(stream.Withdrawn).Should(gomega.Equal(uint64(0)))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender, asset6ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(uint64(559_000)))
+	})
+
+	ginkgo.It("rejects stream withdrawal by sender", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.WithdrawStream{
+				Stream: stream1ID,
+				Asset:  asset6ID,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("unauthorized"))
+	})
+
+	ginkgo.It("withdraw from a stream", func() {
+		// Wait for some of the stream to accrue
+		time.Sleep(2 * time.Second)
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.WithdrawStream{
+				Stream: stream1ID,
+				Asset:  asset6ID,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		_, stream, err := instances[0].cli.Stream(context.TODO(), stream1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(stream.Withdrawn).Should(gomega.BeNumerically(">", 0))
+		gomega.Ω(stream.Withdrawn).Should(gomega.BeNumerically("<", 1000))
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, asset6ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(stream.Withdrawn))
+	})
+
+	ginkgo.It("rejects stream cancel by recipient", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CancelStream{
+				Stream:    stream1ID,
+				Asset:     asset6ID,
+				Recipient: rsender2,
+			},
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("unauthorized"))
+	})
+
+	ginkgo.It("cancel a stream", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CancelStream{
+				Stream:    stream1ID,
+				Asset:     asset6ID,
+				Recipient: rsender2,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		exists, _, err := instances[0].cli.Stream(context.TODO(), stream1ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeFalse())
+
+		// The deposit is split between the sender and the recipient
+		senderBalance, err := instances[0].cli.Balance(context.TODO(), sender, asset6ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		recipientBalance, err := instances[0].cli.Balance(context.TODO(), sender2, asset6ID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(senderBalance + recipientBalance).Should(gomega.Equal(uint64(560_000)))
+	})
+
+	ginkgo.It("rejects asset with an invalid symbol", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAsset{
+				Symbol: []byte("mario"),
+				Name:   []byte("Mario Coin"),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("symbol must be uppercase alphanumeric"))
+	})
+
+	ginkgo.It("rejects asset with too many decimals", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAsset{
+				Symbol:   []byte("MARIO"),
+				Decimals: actions.MaxDecimals + 1,
+				Name:     []byte("Mario Coin"),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("decimals are too large"))
+	})
+
+	ginkgo.It("rejects asset with a uri but no uri hash", func() {
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAsset{
+				Symbol: []byte("MARIO"),
+				Name:   []byte("Mario Coin"),
+				URI:    []byte("ipfs://mario"),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+		gomega.Ω(string(result.Output)).
+			Should(gomega.ContainSubstring("uri hash is empty"))
+	})
+
+	ginkgo.It("create an asset with structured metadata", func() {
+		uriHash := ids.GenerateTestID()
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAsset{
+				Symbol:   []byte("MARIO"),
+				Decimals: 2,
+				Name:     []byte("Mario Coin"),
+				URI:      []byte("ipfs://mario"),
+				URIHash:  uriHash,
+				Metadata: []byte("it's-a me"),
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), tx.ID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Symbol).Should(gomega.Equal([]byte("MARIO")))
+		gomega.Ω(asset.Decimals).Should(gomega.Equal(uint8(2)))
+		gomega.Ω(asset.Name).Should(gomega.Equal([]byte("Mario Coin")))
+		gomega.Ω(asset.URI).Should(gomega.Equal([]byte("ipfs://mario")))
+		gomega.Ω(asset.URIHash).Should(gomega.Equal(uriHash))
+		gomega.Ω(asset.Metadata).Should(gomega.Equal([]byte("it's-a me")))
+		decimals, err := instances[0].cli.Decimals(context.TODO(), tx.ID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(decimals).Should(gomega.Equal(uint8(2)))
+
+		// The native asset uses the symbol and decimals of the chain
+		exists, asset, err = instances[0].cli.Asset(context.TODO(), ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(string(asset.Symbol)).Should(gomega.Equal(tconsts.Symbol))
+		gomega.Ω(asset.Decimals).Should(gomega.Equal(uint8(tconsts.Decimals)))
+	})
+
+	ginkgo.It("execute a sequence of actions", func() {
+		nativeBalance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Sequence{
+				Actions: []chain.Action{
+					&actions.CreateAsset{
+						Symbol:   []byte("SEQ"),
+						Name:     []byte("sequence"),
+						Metadata: []byte("sequence"),
+					},
+					&actions.MintNFT{
+						Collection: collection1ID,
+						ID:         4,
+						To:         rsender2,
+						Metadata:   []byte("piece 4"),
+					},
+					&actions.Transfer{
+						To:    rsender2,
+						Value: 100,
+					},
+				},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeTrue())
+		sr, err := actions.UnmarshalSequenceResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(sr.Outputs).Should(gomega.HaveLen(3))
+
+		// Objects created in a sequence are identified by their step ID
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), actions.SequenceStepID(tx.ID(), 0))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Symbol).Should(gomega.Equal([]byte("SEQ")))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(sender))
+
+		exists, owner, _, err := instances[0].cli.NFT(context.TODO(), collection1ID, 4)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(owner).Should(gomega.Equal(sender2))
+		nfts, err := instances[0].cli.NFTs(context.TODO(), collection1ID, 0)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(nfts).Should(gomega.Equal([]uint64{1, 4}))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(nativeBalance + 100))
+	})
+
+	ginkgo.It("create, mint and transfer ownership of an asset in a sequence", func() {
+		recipients := make([]crypto.PublicKey, 3)
+		for i := range recipients {
+			other, err := crypto.GeneratePrivateKey()
+			gomega.Ω(err).Should(gomega.BeNil())
+			recipients[i] = other.PublicKey()
+		}
+
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Sequence{
+				Actions: []chain.Action{
+					&actions.CreateAsset{
+						Symbol:   []byte("TEAM"),
+						Name:     []byte("team"),
+						Metadata: []byte("team"),
+					},
+					&actions.MintAsset{
+						To:    recipients[0],
+						Asset: actions.SequenceStepRef(0),
+						Value: 100,
+					},
+					&actions.MintAsset{
+						To:    recipients[1],
+						Asset: actions.SequenceStepRef(0),
+						Value: 200,
+					},
+					&actions.MintAsset{
+						To:    recipients[2],
+						Asset: actions.SequenceStepRef(0),
+						Value: 300,
+					},
+					&actions.TransferAssetOwnership{
+						Asset: actions.SequenceStepRef(0),
+						To:    rsender2,
+					},
+				},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		assetID := actions.SequenceStepID(tx.ID(), 0)
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), assetID)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Supply).Should(gomega.Equal(uint64(600)))
+		gomega.Ω(asset.Owner).Should(gomega.Equal(sender2))
+		for i, recipient := range recipients {
+			balance, err := instances[0].cli.Balance(context.TODO(), utils.Address(recipient), assetID)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(balance).Should(gomega.Equal(uint64(100 * (i + 1))))
+		}
+
+		// References are only resolved to earlier steps
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Sequence{
+				Actions: []chain.Action{
+					&actions.MintAsset{
+						To:    recipients[0],
+						Asset: actions.SequenceStepRef(1),
+						Value: 100,
+					},
+					&actions.CreateAsset{
+						Symbol:   []byte("TEAM"),
+						Name:     []byte("team"),
+						Metadata: []byte("team"),
+					},
+				},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		sr, err := actions.UnmarshalSequenceResult(results[0].Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(sr.Outputs[0]).Should(gomega.Equal(actions.OutputAssetMissing))
+	})
+
+	ginkgo.It("indexes memos of transfers in a sequence", func() {
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Sequence{
+				Actions: []chain.Action{
+					&actions.TransferWithMemo{
+						To:    rsender2,
+						Value: 1,
+						Memo:  []byte("invoice-43"),
+					},
+					&actions.Transfer{
+						To:    rsender2,
+						Value: 1,
+					},
+					&actions.TransferWithMemo{
+						To:    rsender2,
+						Value: 1,
+						Memo:  []byte("invoice-44"),
+					},
+				},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Memos are indexed by the step ID of the transfer
+		memo, err := instances[0].cli.Memo(context.TODO(), actions.SequenceStepID(tx.ID(), 0))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(memo).Should(gomega.Equal([]byte("invoice-43")))
+		memo, err = instances[0].cli.Memo(context.TODO(), actions.SequenceStepID(tx.ID(), 1))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(memo).Should(gomega.BeEmpty())
+		memo, err = instances[0].cli.Memo(context.TODO(), actions.SequenceStepID(tx.ID(), 2))
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(memo).Should(gomega.Equal([]byte("invoice-44")))
+
+		// The sequence itself has no memo
+		found, success, _, memo, err := instances[0].cli.Tx(context.TODO(), tx.ID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(found).Should(gomega.BeTrue())
+		gomega.Ω(success).Should(gomega.BeTrue())
+		gomega.Ω(memo).Should(gomega.BeEmpty())
+	})
+
+	ginkgo.It("rejects sequence if any action fails", func() {
+		nativeBalance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Sequence{
+				Actions: []chain.Action{
+					&actions.Transfer{
+						To:    rsender2,
+						Value: 100,
+					},
+					&actions.MintNFT{
+						Collection: collection1ID,
+						ID:         5,
+						To:         rsender2,
+						Metadata:   []byte("piece 5"),
+					},
+					&actions.MintNFT{
+						Collection: collection1ID,
+						ID:         5,
+						To:         rsender2,
+						Metadata:   []byte("piece 5"),
+					},
+				},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		result := results[0]
+		gomega.Ω(result.Success).Should(gomega.BeFalse())
+
+		// The last output is the output of the step that failed
+		sr, err := actions.UnmarshalSequenceResult(result.Output)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(sr.Outputs).Should(gomega.HaveLen(3))
+		gomega.Ω(sr.Outputs[2]).Should(gomega.Equal(actions.OutputNFTAlreadyExists))
+
+		// No step should have been applied
+		exists, _, _, err := instances[0].cli.NFT(context.TODO(), collection1ID, 5)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeFalse())
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(nativeBalance))
+	})
+
+	ginkgo.It("transfer from a secp256k1 key", func() {
+		secpFactory := secp256k1.Factory{}
+		secpPriv, err := secpFactory.NewPrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		rsecpSender := utils.SECP256K1Actor(secpPriv.PublicKey().Bytes())
+		secpSender := utils.Address(rsecpSender)
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rsecpSender,
+				Value: 100_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		nativeBalance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewSECP256K1Factory(secpPriv),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(auth.GetActor(tx.Auth)).Should(gomega.Equal(rsecpSender))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), secpSender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeNumerically("<", uint64(100_000-1)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(nativeBalance + 1))
+	})
+
+	ginkgo.It("rejects secp256k1 auth with the wrong signer", func() {
+		secpFactory := secp256k1.Factory{}
+		secpPriv, err := secpFactory.NewPrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		otherPriv, err := secpFactory.NewPrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		actionRegistry, authRegistry := instances[0].vm.Registry()
+		tx := chain.NewTx(
+			&chain.Base{
+				ChainID:   instances[0].chainID,
+				Timestamp: time.Now().Unix() + 10,
+				UnitPrice: 1000,
+			},
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+		)
+		msg, err := tx.Digest(actionRegistry)
+		gomega.Ω(err).To(gomega.BeNil())
+		sauth, err := auth.NewSECP256K1Factory(secpPriv).Sign(msg, tx.Action)
+		gomega.Ω(err).To(gomega.BeNil())
+		// Claim the signature was produced by a different key
+		sauth.(*auth.SECP256K1).Signer = otherPriv.PublicKey().Bytes()
+		tx.Auth = sauth
+		p := codec.NewWriter(consts.MaxInt)
+		gomega.Ω(tx.Marshal(p, actionRegistry, authRegistry)).To(gomega.BeNil())
+		gomega.Ω(p.Err()).To(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(
+			context.Background(),
+			p.Bytes(),
+		)
+		gomega.Ω(err).To(gomega.Not(gomega.BeNil()))
+	})
+
+	ginkgo.It("create a multisig and transfer from it", func() {
+		priv3, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		signers := []crypto.PublicKey{rsender, rsender2, priv3.PublicKey()}
+		sort.Slice(signers, func(i, j int) bool {
+			return bytes.Compare(signers[i][:], signers[j][:]) < 0
+		})
+		create := &actions.CreateMultisig{
+			Threshold: 2,
+			Signers:   signers,
+		}
+		multisig := utils.Address(create.Account())
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			create,
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		exists, threshold, msigners, err := instances[0].cli.Multisig(context.TODO(), multisig)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(threshold).Should(gomega.Equal(uint8(2)))
+		gomega.Ω(msigners).Should(gomega.HaveLen(3))
+
+		// Fund the multisig account
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    create.Account(),
+				Value: 100_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// A single signer cannot sign for the account
+		_, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewMultisigFactory(2, signers, priv3),
+		)
+		gomega.Ω(err).Should(gomega.MatchError(auth.ErrNotEnoughSignatures))
+
+		nativeBalance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewMultisigFactory(2, signers, priv, priv3),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(auth.GetActor(tx.Auth)).Should(gomega.Equal(create.Account()))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), multisig, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeNumerically("<", uint64(100_000-1)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(nativeBalance + 1))
+
+		// The same multisig cannot be registered twice (by anyone)
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			create,
+			factory2,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(results[0].Output).Should(gomega.Equal(actions.OutputMultisigExists))
+	})
+
+	ginkgo.It("rejects multisig auth with a repeated signature", func() {
+		signers := []crypto.PublicKey{rsender, rsender2}
+		sort.Slice(signers, func(i, j int) bool {
+			return bytes.Compare(signers[i][:], signers[j][:]) < 0
+		})
+
+		actionRegistry, authRegistry := instances[0].vm.Registry()
+		tx := chain.NewTx(
+			&chain.Base{
+				ChainID:   instances[0].chainID,
+				Timestamp: time.Now().Unix() + 10,
+				UnitPrice: 1000,
+			},
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+		)
+		msg, err := tx.Digest(actionRegistry)
+		gomega.Ω(err).To(gomega.BeNil())
+		mauth, err := auth.NewMultisigFactory(1, signers, priv).Sign(msg, tx.Action)
+		gomega.Ω(err).To(gomega.BeNil())
+		// Count the only signature twice to reach a threshold of 2
+		multisig := mauth.(*auth.Multisig)
+		multisig.Threshold = 2
+		multisig.Signatures = append(multisig.Signatures, multisig.Signatures[0])
+		tx.Auth = multisig
+		p := codec.NewWriter(consts.MaxInt)
+		gomega.Ω(tx.Marshal(p, actionRegistry, authRegistry)).To(gomega.BeNil())
+		gomega.Ω(p.Err()).To(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(
+			context.Background(),
+			p.Bytes(),
+		)
+		gomega.Ω(err).To(gomega.Not(gomega.BeNil()))
+	})
+
+	ginkgo.It("rejects a multisig signature replayed as an ed25519 signature", func() {
+		signers := []crypto.PublicKey{rsender, rsender2}
+		sort.Slice(signers, func(i, j int) bool {
+			return bytes.Compare(signers[i][:], signers[j][:]) < 0
+		})
+
+		actionRegistry, authRegistry := instances[0].vm.Registry()
+		tx := chain.NewTx(
+			&chain.Base{
+				ChainID:   instances[0].chainID,
+				Timestamp: time.Now().Unix() + 10,
+				UnitPrice: 1000,
+			},
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+		)
+		msg, err := tx.Digest(actionRegistry)
+		gomega.Ω(err).To(gomega.BeNil())
+		mauth, err := auth.NewMultisigFactory(1, signers, priv).Sign(msg, tx.Action)
+		gomega.Ω(err).To(gomega.BeNil())
+		// Reuse the signature of [priv] to issue the same action from its own
+		// account
+		sig := mauth.(*auth.Multisig).Signatures[0]
+		tx.Auth = &auth.ED25519{
+			Signer:    signers[sig.Signer],
+			Signature: sig.Signature,
+		}
+		p := codec.NewWriter(consts.MaxInt)
+		gomega.Ω(tx.Marshal(p, actionRegistry, authRegistry)).To(gomega.BeNil())
+		gomega.Ω(p.Err()).To(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(
+			context.Background(),
+			p.Bytes(),
+		)
+		gomega.Ω(err).To(gomega.Not(gomega.BeNil()))
+	})
+
+	ginkgo.It("spends with a session key within its limits", func() {
+		priv3, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		sessionKey := priv3.PublicKey()
+		transferType, _, _, ok := tconsts.ActionRegistry.LookupType(&actions.Transfer{})
+		gomega.Ω(ok).Should(gomega.BeTrue())
+		expiry := time.Now().Unix() + 3600
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.RegisterSessionKey{
+				SessionKey: sessionKey,
+				Expiry:     expiry,
+				Actions:    []uint8{transferType},
+				Limits:     []*auth.Amount{{Asset: ids.Empty, Value: 100_000}},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		nativeBalance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		senderBalance, err := instances[0].cli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		sessionFactory := auth.NewSessionKeyFactory(rsender, expiry, priv3)
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 6,
+			},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(auth.GetActor(tx.Auth)).Should(gomega.Equal(rsender))
+		gomega.Ω(auth.GetSigner(tx.Auth)).Should(gomega.Equal(sessionKey))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(nativeBalance + 6))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		spent := senderBalance - balance // value and fees
+		gomega.Ω(spent).Should(gomega.BeNumerically(">", 6))
+		exists, sexpiry, allowed, limits, err := instances[0].cli.SessionKey(
+			context.TODO(),
+			sender,
+			utils.Address(sessionKey),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(sexpiry).Should(gomega.Equal(expiry))
+		gomega.Ω(allowed).Should(gomega.Equal([]uint8{transferType}))
+		gomega.Ω(limits).Should(gomega.HaveLen(1))
+		gomega.Ω(limits[0].Value).Should(gomega.Equal(100_000 - spent))
+
+		// Spending more than the remaining allowance is rejected
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: limits[0].Value + 1,
+			},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring("spending limit exceeded"))
+
+		// Actions that are not allowed are rejected
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.BurnAsset{
+				Asset: ids.GenerateTestID(),
+				Value: 1,
+			},
+			sessionFactory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring("action not allowed for session key"))
+	})
+
+	ginkgo.It("rejects session key transactions with fees above the native limit", func() {
+		priv3, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		transferType, _, _, ok := tconsts.ActionRegistry.LookupType(&actions.Transfer{})
+		gomega.Ω(ok).Should(gomega.BeTrue())
+		expiry := time.Now().Unix() + 3600
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.RegisterSessionKey{
+				SessionKey: priv3.PublicKey(),
+				Expiry:     expiry,
+				Actions:    []uint8{transferType},
+				Limits:     []*auth.Amount{{Asset: ids.Empty, Value: 1}},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// The transfer is within the limit but its fees are not
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewSessionKeyFactory(rsender, expiry, priv3),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background()).Error()).
+			Should(gomega.ContainSubstring("spending limit exceeded"))
+	})
+
+	ginkgo.It("rejects session key actions that spending limits can't cap", func() {
+		priv3, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		expiry := time.Now().Unix() + 3600
+		uncapped := []chain.Action{
+			&actions.TransferFrom{
+				Owner: rsender2,
+				To:    rsender,
+				Value: 1,
+			},
+			&actions.TransferNFT{
+				Collection: collection1ID,
+				ID:         1,
+				To:         rsender2,
+			},
+			&actions.BurnNFT{
+				Collection: collection1ID,
+				ID:         1,
+			},
+			&actions.Clawback{
+				Asset: asset4ID,
+				From:  rsender2,
+				Value: 1,
+			},
+			&actions.TransferAssetOwnership{
+				Asset: asset1ID,
+				To:    rsender2,
+			},
+		}
+		allowed := make([]uint8, len(uncapped))
+		for i, action := range uncapped {
+			typeID, _, _, ok := tconsts.ActionRegistry.LookupType(action)
+			gomega.Ω(ok).Should(gomega.BeTrue())
+			allowed[i] = typeID
+		}
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.RegisterSessionKey{
+				SessionKey: priv3.PublicKey(),
+				Expiry:     expiry,
+				Actions:    allowed,
+				Limits:     []*auth.Amount{{Asset: ids.Empty, Value: 100_000}},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// Every action is allowed, but none of them declares its spends
+		sessionFactory := auth.NewSessionKeyFactory(rsender, expiry, priv3)
+		for _, action := range uncapped {
+			submit, _, _, err = instances[0].cli.GenerateTransaction(
+				context.Background(),
+				nil,
+				action,
+				sessionFactory,
+			)
+			gomega.Ω(err).Should(gomega.BeNil())
+			gomega.Ω(submit(context.Background()).Error()).
+				Should(gomega.ContainSubstring("action can't be capped by session key"))
+		}
+	})
+
+	ginkgo.It("rejects an ed25519 signature replayed as a session key signature", func() {
+		priv3, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		transferType, _, _, ok := tconsts.ActionRegistry.LookupType(&actions.Transfer{})
+		gomega.Ω(ok).Should(gomega.BeTrue())
+		expiry := time.Now().Unix() + 3600
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.RegisterSessionKey{
+				SessionKey: priv3.PublicKey(),
+				Expiry:     expiry,
+				Actions:    []uint8{transferType},
+				Limits:     []*auth.Amount{{Asset: ids.Empty, Value: 10}},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		actionRegistry, authRegistry := instances[0].vm.Registry()
+		tx := chain.NewTx(
+			&chain.Base{
+				ChainID:   instances[0].chainID,
+				Timestamp: time.Now().Unix() + 10,
+				UnitPrice: 1000,
+			},
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+		)
+		msg, err := tx.Digest(actionRegistry)
+		gomega.Ω(err).To(gomega.BeNil())
+		// Reuse a signature the session key produced for its own account
+		tx.Auth = &auth.SessionKey{
+			Account:   rsender,
+			Signer:    priv3.PublicKey(),
+			Expiry:    expiry,
+			Signature: crypto.Sign(msg, priv3),
+		}
+		p := codec.NewWriter(consts.MaxInt)
+		gomega.Ω(tx.Marshal(p, actionRegistry, authRegistry)).To(gomega.BeNil())
+		gomega.Ω(p.Err()).To(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(
+			context.Background(),
+			p.Bytes(),
+		)
+		gomega.Ω(err).To(gomega.Not(gomega.BeNil()))
+	})
+
+	ginkgo.It("rejects session keys registered by a session key", func() {
+		priv3, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		registerType, _, _, ok := tconsts.ActionRegistry.LookupType(&actions.RegisterSessionKey{})
+		gomega.Ω(ok).Should(gomega.BeTrue())
+		expiry := time.Now().Unix() + 3600
+
+		// Expiry must be in the future
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.RegisterSessionKey{
+				SessionKey: priv3.PublicKey(),
+				Expiry:     time.Now().Unix() - 3600,
+				Actions:    []uint8{registerType},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(results[0].Output).Should(gomega.Equal(actions.OutputExpiryInPast))
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.RegisterSessionKey{
+				SessionKey: priv3.PublicKey(),
+				Expiry:     expiry,
+				Actions:    []uint8{registerType},
+				// Fees count towards the native limit
+				Limits: []*auth.Amount{{Asset: ids.Empty, Value: 100_000}},
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		submit, _, _, err = instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.RegisterSessionKey{
+				SessionKey: rsender2,
+				Expiry:     expiry,
+				Actions:    []uint8{registerType},
+			},
+			auth.NewSessionKeyFactory(rsender, expiry, priv3),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeFalse())
+		gomega.Ω(results[0].Output).Should(gomega.Equal(actions.OutputSessionKeyNotAllowed))
+	})
+
+	ginkgo.It("sponsor pays the fees of an account without funds", func() {
+		priv3, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+		user := utils.Address(priv3.PublicKey())
+
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.CreateAsset{
+				Symbol: []byte("SPONSOR"),
+				Name:   []byte("sponsored"),
+			},
+			auth.NewSponsoredFactory(priv3, rsender),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		// The transaction can't be issued until the sponsor countersigns it
+		gomega.Ω(submit(context.Background())).ShouldNot(gomega.BeNil())
+
+		// Only the named sponsor can countersign
+		_, _, _, err = instances[0].cli.SponsorTransaction(context.Background(), tx, priv2)
+		gomega.Ω(err).Should(gomega.MatchError(auth.ErrWrongSponsor))
+
+		sponsorBalance, err := instances[0].cli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, fee, err := instances[0].cli.SponsorTransaction(context.Background(), tx, priv)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(auth.GetActor(tx.Auth)).Should(gomega.Equal(priv3.PublicKey()))
+		gomega.Ω(tx.Payer()).Should(gomega.Equal(string(rsender[:])))
+
+		exists, asset, err := instances[0].cli.Asset(context.TODO(), tx.ID())
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(exists).Should(gomega.BeTrue())
+		gomega.Ω(asset.Owner).Should(gomega.Equal(user))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), user, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeZero())
+		balance, err = instances[0].cli.Balance(context.TODO(), sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeNumerically("<", sponsorBalance))
+		gomega.Ω(balance).Should(gomega.BeNumerically(">=", sponsorBalance-fee))
+	})
+
+	ginkgo.It("rejects a sponsor signature replayed as an ed25519 signature", func() {
+		priv3, err := crypto.GeneratePrivateKey()
+		gomega.Ω(err).Should(gomega.BeNil())
+
+		actionRegistry, authRegistry := instances[0].vm.Registry()
+		tx := chain.NewTx(
+			&chain.Base{
+				ChainID:   instances[0].chainID,
+				Timestamp: time.Now().Unix() + 10,
+				UnitPrice: 1000,
+			},
+			nil,
+			&actions.Transfer{
+				To:    priv3.PublicKey(),
+				Value: 1,
+			},
+		)
+		msg, err := tx.Digest(actionRegistry)
+		gomega.Ω(err).To(gomega.BeNil())
+		uauth, err := auth.NewSponsoredFactory(priv3, rsender).Sign(msg, tx.Action)
+		gomega.Ω(err).To(gomega.BeNil())
+		sauth, err := auth.NewSponsorFactory(priv, uauth.(*auth.Sponsored)).Sign(msg, tx.Action)
+		gomega.Ω(err).To(gomega.BeNil())
+		// Reuse the signature of the sponsor to issue the action from its own
+		// account
+		tx.Auth = &auth.ED25519{
+			Signer:    rsender,
+			Signature: sauth.(*auth.Sponsored).SponsorSignature,
+		}
+		p := codec.NewWriter(consts.MaxInt)
+		gomega.Ω(tx.Marshal(p, actionRegistry, authRegistry)).To(gomega.BeNil())
+		gomega.Ω(p.Err()).To(gomega.BeNil())
+		_, err = instances[0].cli.SubmitTx(
+			context.Background(),
+			p.Bytes(),
+		)
+		gomega.Ω(err).To(gomega.Not(gomega.BeNil()))
+	})
+
+	ginkgo.It("transfer from a p256 key", func() {
+		p256Priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		gomega.Ω(err).Should(gomega.BeNil())
+		rp256Sender := utils.P256Actor(elliptic.MarshalCompressed(elliptic.P256(), p256Priv.X, p256Priv.Y))
+		p256Sender := utils.Address(rp256Sender)
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rp256Sender,
+				Value: 100_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		nativeBalance, err := instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		submit, tx, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    rsender2,
+				Value: 1,
+			},
+			auth.NewP256Factory(p256Priv),
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+		gomega.Ω(auth.GetActor(tx.Auth)).Should(gomega.Equal(rp256Sender))
+
+		balance, err := instances[0].cli.Balance(context.TODO(), p256Sender, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.BeNumerically("<", uint64(100_000-1)))
+		balance, err = instances[0].cli.Balance(context.TODO(), sender2, ids.Empty)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(balance).Should(gomega.Equal(nativeBalance + 1))
+	})
+
+	ginkgo.It("transfer with a webauthn assertion", func() {
+		p256Priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		gomega.Ω(err).Should(gomega.BeNil())
+		signer := elliptic.MarshalCompressed(elliptic.P256(), p256Priv.X, p256Priv.Y)
+
+		submit, _, _, err := instances[0].cli.GenerateTransaction(
+			context.Background(),
+			nil,
+			&actions.Transfer{
+				To:    utils.P256Actor(signer),
+				Value: 100_000,
+			},
+			factory,
+		)
+		gomega.Ω(err).Should(gomega.BeNil())
+		gomega.Ω(submit(context.Background())).Should(gomega.BeNil())
+		accept := expectBlk(instances[0])
+		results := accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+
+		// sign returns a transaction authorized by an assertion over
+		// [challenge], as an authenticator would produce it
+		actionRegistry, authRegistry := instances[0].vm.Registry()
+		sign := func(value uint64, challenge func([]byte) string) []byte {
+			tx := chain.NewTx(
+				&chain.Base{
+					ChainID:   instances[0].chainID,
+					Timestamp: time.Now().Unix() + 10,
+					UnitPrice: 1,
+				},
+				nil,
+				&actions.Transfer{
+					To:    rsender2,
+					Value: value,
+				},
+			)
+			msg, err := tx.Digest(actionRegistry)
+			gomega.Ω(err).To(gomega.BeNil())
+			authenticatorData := make([]byte, 37)
+			authenticatorData[32] = 0x01 // user present
+			clientDataJSON := []byte(fmt.Sprintf(
+				`{"type":"webauthn.get","challenge":"%s","origin":"https://wallet.example"}`,
+				challenge(msg),
+			))
+			clientDataHash := sha256.Sum256(clientDataJSON)
+			digest := sha256.Sum256(append(bytes.Clone(authenticatorData), clientDataHash[:]...))
+			r, s, err := ecdsa.Sign(rand.Reader, p256Priv, digest[:])
+			gomega.Ω(err).To(gomega.BeNil())
+			tx.Auth = &auth.P256{
+				Signer:            signer,
+				AuthenticatorData: authenticatorData,
+				ClientDataJSON:    clientDataJSON,
+				Signature:         auth.P256Signature(r, s),
+			}
+			p := codec.NewWriter(consts.MaxInt)
+			gomega.Ω(tx.Marshal(p, actionRegistry, authRegistry)).To(gomega.BeNil())
+			gomega.Ω(p.Err()).To(gomega.BeNil())
+			return p.Bytes()
+		}
+
+		// The challenge must be derived from the transaction
+		_, err = instances[0].cli.SubmitTx(
+			context.Background(),
+			sign(1, func([]byte) string { return auth.WebAuthnChallenge([]byte("other")) }),
+		)
+		gomega.Ω(err).To(gomega.Not(gomega.BeNil()))
+
+		_, err = instances[0].cli.SubmitTx(context.Background(), sign(2, auth.WebAuthnChallenge))
+		gomega.Ω(err).To(gomega.BeNil())
+		accept = expectBlk(instances[0])
+		results = accept()
+		gomega.Ω(results).Should(gomega.HaveLen(1))
+		gomega.Ω(results[0].Success).Should(gomega.BeTrue())
+	})
+
+	ginkgo.It("registers auths in the order of their IDs", func() {
+		for id, a := range map[uint8]chain.Auth{
+			auth.ED25519ID:    &auth.ED25519{},
+			auth.SECP256K1ID:  &auth.SECP256K1{},
+			auth.MultisigID:   &auth.Multisig{},
+			auth.SessionKeyID: &auth.SessionKey{},
+			auth.SponsoredID:  &auth.Sponsored{},
+			auth.P256ID:       &auth.P256{},
+		} {
+			typeID, _, _, ok := tconsts.AuthRegistry.LookupType(a)
+			gomega.Ω(ok).Should(gomega.BeTrue())
+			gomega.Ω(typeID).Should(gomega.Equal(id))
+		}
+	})
+})
+
+func expectBlk(i instance) func() []*chain.Result {
+	return expectBlkWithContext(i, nil)
+}
+
+// expectWarpBlk builds and verifies a block with a context, which is required
+// to include transactions with warp messages.
+func expectWarpBlk(i instance) func() []*chain.Result {
+	return expectBlkWithContext(i, &smblock.Context{PChainHeight: 1})
+}
+
+func expectBlkWithContext(i instance, bctx *smblock.Context) func() []*chain.Result {
+	ctx := context.TODO()
+
+	// manually signal ready
+	i.vm.Builder().TriggerBuild()
+	// manually ack ready sig as in engine
+	<-i.toEngine
+
+	var (
+		blk snowman.Block
+		err error
+	)
+	if bctx == nil {
+		blk, err = i.vm.BuildBlock(ctx)
+	} else {
+		blk, err = i.vm.BuildBlockWithContext(ctx, bctx)
+	}
+	gomega.Ω(err).To(gomega.BeNil())
+	gomega.Ω(blk).To(gomega.Not(gomega.BeNil()))
+
+	if bctx == nil {
+		gomega.Ω(blk.Verify(ctx)).To(gomega.BeNil())
+	} else {
+		gomega.Ω(blk.(smblock.WithVerifyContext).VerifyWithContext(ctx, bctx)).To(gomega.BeNil())
+	}
+	gomega.Ω(blk.Status()).To(gomega.Equal(choices.Processing))
+
+	err = i.vm.SetPreference(ctx, blk.ID())
+	gomega.Ω(err).To(gomega.BeNil())
+
+	return func() []*chain.Result {
+		gomega.Ω(blk.Accept(ctx)).To(gomega.BeNil())
+		gomega.Ω(blk.Status()).To(gomega.Equal(choices.Accepted))
+
+		lastAccepted, err := i.vm.LastAccepted(ctx)
+		gomega.Ω(err).To(gomega.BeNil())
+		gomega.Ω(lastAccepted).To(gomega.Equal(blk.ID()))
+		return blk.(*chain.StatelessBlock).Results()
+	}
+}
+
+// newWarpMessage returns a warp message from [sourceChainID] to the embedded
+// VMs with [transfer] as its payload. It is signed by [signer] or, if [signer]
+// is nil, not signed at all.
+func newWarpMessage(
+	sourceChainID ids.ID,
+	transfer *actions.WarpTransfer,
+	signer warp.Signer,
+) *warp.Message {
+	payload, err := transfer.Marshal()
+	gomega.Ω(err).Should(gomega.BeNil())
+	unsignedMsg, err := warp.NewUnsignedMessage(sourceChainID, instances[0].chainID, payload)
+	gomega.Ω(err).Should(gomega.BeNil())
+	signature := &warp.BitSetSignature{}
+	if signer != nil {
+		sig, err := signer.Sign(unsignedMsg)
+		gomega.Ω(err).Should(gomega.BeNil())
+		signature.Signers = set.NewBits(0).Bytes()
+		copy(signature.Signature[:], sig)
+	}
+	msg, err := warp.NewMessage(unsignedMsg, signature)
+	gomega.Ω(err).Should(gomega.BeNil())
+	return msg
 }
 
 var _ common.AppSender = &appSender{}
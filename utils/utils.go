@@ -1,15 +1,89 @@
 package utils
 
 import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/hypersdk/crypto"
 
 	"github.com/rafael-abuawad/samplevm/consts"
 )
 
+var ErrTooManyDecimals = errors.New("too many decimal places")
+
+const (
+	// multisigDomain separates the preimage of a [MultisigActor] from the
+	// preimage of a [SECP256K1Actor].
+	multisigDomain = "multisig"
+
+	// p256Domain separates the preimage of a [P256Actor] from the preimage of
+	// a [SECP256K1Actor], as compressed keys on both curves have the same
+	// length.
+	p256Domain = "p256"
+)
+
+// Address returns the address of the actor [pk]. An actor is either an
+// ED25519 public key or the [SECP256K1Actor] or [P256Actor] of an ECDSA public
+// key.
 func Address(pk crypto.PublicKey) string {
 	return crypto.Address(consts.HRP, pk)
 }
 
+// SECP256K1Actor returns the actor of the compressed secp256k1 public key
+// [pk]. Accounts are keyed by a 32-byte [crypto.PublicKey], so the actor of a
+// secp256k1 key is the hash of the key.
+func SECP256K1Actor(pk []byte) crypto.PublicKey {
+	return crypto.PublicKey(hashing.ComputeHash256Array(pk))
+}
+
+// P256Actor returns the actor of the compressed P-256 public key [pk].
+func P256Actor(pk []byte) crypto.PublicKey {
+	b := make([]byte, len(p256Domain)+len(pk))
+	copy(b, p256Domain)
+	copy(b[len(p256Domain):], pk)
+	return crypto.PublicKey(hashing.ComputeHash256Array(b))
+}
+
+// MultisigActor returns the actor of the multisig account that requires
+// [threshold] signatures from [signers].
+func MultisigActor(threshold uint8, signers []crypto.PublicKey) crypto.PublicKey {
+	b := make([]byte, len(multisigDomain)+1+len(signers)*crypto.PublicKeyLen)
+	copy(b, multisigDomain)
+	b[len(multisigDomain)] = threshold
+	for i, signer := range signers {
+		copy(b[len(multisigDomain)+1+i*crypto.PublicKeyLen:], signer[:])
+	}
+	return crypto.PublicKey(hashing.ComputeHash256Array(b))
+}
+
 func ParseAddress(s string) (crypto.PublicKey, error) {
 	return crypto.ParseAddress(consts.HRP, s)
 }
+
+// FormatBalance formats [bal] (denominated in the smallest unit of an asset)
+// as a decimal with [decimals] places.
+func FormatBalance(bal uint64, decimals uint8) string {
+	s := strconv.FormatUint(bal, 10)
+	if decimals == 0 {
+		return s
+	}
+	d := int(decimals)
+	if len(s) <= d {
+		s = strings.Repeat("0", d-len(s)+1) + s
+	}
+	return s[:len(s)-d] + "." + s[len(s)-d:]
+}
+
+// ParseBalance parses a decimal [bal] with at most [decimals] places into the
+// smallest unit of an asset.
+func ParseBalance(bal string, decimals uint8) (uint64, error) {
+	whole, frac, _ := strings.Cut(bal, ".")
+	if len(frac) > int(decimals) {
+		return 0, ErrTooManyDecimals
+	}
+	// Pad the fractional part so that [bal] can be parsed as an integer
+	// without losing precision.
+	return strconv.ParseUint(whole+frac+strings.Repeat("0", int(decimals)-len(frac)), 10, 64)
+}